@@ -4,7 +4,9 @@ go 1.15
 
 require (
 	github.com/go-openapi/runtime v0.19.26
+	github.com/go-openapi/strfmt v0.19.5
 	github.com/google/uuid v1.1.1
+	github.com/hashicorp/go-multierror v1.0.0
 	github.com/hashicorp/terraform v0.14.6
 	github.com/jefflinse/square-connect v0.0.0-20200926230956-adba8c780e46
 )
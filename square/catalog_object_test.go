@@ -0,0 +1,213 @@
+package square
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/jefflinse/terraform-provider-square/square/client"
+)
+
+// Deleting an object outside of Terraform leaves a plan to create it again.
+func TestAccSquareCatalogObject_disappears(t *testing.T) {
+	source := testAccImageSource(t, testAccPNG)
+
+	for _, c := range []struct {
+		name   string
+		config string
+	}{
+		{"square_catalog_category.test", testAccCatalogCategoryConfig("test", "Apparel")},
+		{"square_catalog_discount.test", testAccSquareCatalogDiscountConfigPercentage("Ten Percent", "10.0")},
+		{"square_catalog_image.test", testAccSquareCatalogImageConfig(source, "Front", "T-shirt front")},
+		{"square_catalog_item.test", testAccCatalogItemConfig("test", "T-shirt")},
+		{"square_catalog_item_option.test", testAccSquareCatalogItemOptionConfig("Size", false, `values { name = "Small" }`)},
+		{"square_catalog_item_variation.test", testAccSquareCatalogItemVariationConfigVariable("test", "Market Price")},
+		{"square_catalog_measurement_unit.test", testAccSquareCatalogMeasurementUnitConfigPound("test")},
+		{"square_catalog_modifier.test", testAccSquareCatalogModifierConfigFree("Whole milk")},
+		{"square_catalog_modifier_list.test", testAccCatalogModifierListConfig("test", "Milk choice")},
+		{"square_catalog_pricing_rule.test", testAccSquareCatalogPricingRuleConfigHappyHour("Happy hour")},
+		{"square_catalog_product_set.test", testAccSquareCatalogProductSetConfigEverything("Everything")},
+		{"square_catalog_tax.test", testAccSquareCatalogTaxConfig("Sales Tax", "TAX_SUBTOTAL_PHASE", "ADDITIVE", "8.5", false, false)},
+		{"square_catalog_time_period.test", testAccSquareCatalogTimePeriodConfigOnce("2020-12-31T20:00:00", "P1D")},
+	} {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:     func() { testAccPreCheck(t) },
+				Providers:    testAccProviders,
+				CheckDestroy: testAccCheckCatalogObjectsDestroyed(strings.Split(c.name, ".")[0]),
+				Steps: []resource.TestStep{
+					{
+						Config: c.config,
+						Check: resource.ComposeTestCheckFunc(
+							testAccCheckCatalogObjectExists(c.name),
+							testAccCheckCatalogObjectDisappears(c.name),
+						),
+						ExpectNonEmptyPlan: true,
+					},
+				},
+			})
+		})
+	}
+}
+
+// testAccRejectedConfig is a configuration that Terraform must refuse to apply.
+type testAccRejectedConfig struct {
+	name   string
+	config string
+	err    string
+}
+
+// Applies each configuration in a test of its own, expecting it to fail with an error matching
+// its pattern.
+func testAccRunRejectedConfigs(t *testing.T, configs []testAccRejectedConfig) {
+	for _, c := range configs {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      c.config,
+						ExpectError: regexp.MustCompile(c.err),
+					},
+				},
+			})
+		})
+	}
+}
+
+// Verifies that the catalog object for the named resource exists in Square.
+func testAccCheckCatalogObjectExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+
+		if _, err := testAccProvider.Meta().(client.SquareAPI).RetrieveCatalogObject(rs.Primary.ID); err != nil {
+			return fmt.Errorf("catalog object for %s: %w", name, err)
+		}
+
+		return nil
+	}
+}
+
+// Deletes the catalog object for the named resource as if it were deleted outside of Terraform.
+func testAccCheckCatalogObjectDisappears(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+
+		_, err := testAccProvider.Meta().(client.SquareAPI).DeleteCatalogObject(rs.Primary.ID)
+		return err
+	}
+}
+
+// Returns a check that every resource of the specified type has been deleted from Square.
+func testAccCheckCatalogObjectsDestroyed(resourceType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+
+			_, err := testAccProvider.Meta().(client.SquareAPI).RetrieveCatalogObject(rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("%s %s still exists", resourceType, rs.Primary.ID)
+			} else if !errors.Is(err, client.ErrNotFound) {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// Records the ID of the named resource, or verifies that it changed or stayed the same as recorded.
+func testAccCheckResourceID(name string, id *string, changed bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+
+		switch {
+		case *id == "":
+		case changed && rs.Primary.ID == *id:
+			return fmt.Errorf("%s was not replaced", name)
+		case !changed && rs.Primary.ID != *id:
+			return fmt.Errorf("%s was replaced", name)
+		}
+
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+// Records the ID of the named resource's block at index, or verifies that it is the ID recorded.
+func testAccCheckBlockID(name, block string, index int, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+
+		got := rs.Primary.Attributes[fmt.Sprintf("%s.%d.id", block, index)]
+		if got == "" {
+			return fmt.Errorf("%s %d of %s has no ID", block, index, name)
+		}
+
+		if *id == "" {
+			*id = got
+		} else if got != *id {
+			return fmt.Errorf("%s %d of %s has ID %s, want %s", block, index, name, got, *id)
+		}
+
+		return nil
+	}
+}
+
+func testAccCatalogCategoryConfig(label, name string) string {
+	return fmt.Sprintf(`
+resource "square_catalog_category" %q {
+  name = %q
+}
+`, label, name)
+}
+
+func testAccCatalogItemConfig(label, name string) string {
+	return fmt.Sprintf(`
+resource "square_catalog_item" %q {
+  name = %q
+}
+`, label, name)
+}
+
+func testAccCatalogModifierListConfig(label, name string) string {
+	return fmt.Sprintf(`
+resource "square_catalog_modifier_list" %q {
+  name = %q
+}
+`, label, name)
+}
+
+// Formats strings as an HCL list.
+func testAccStringList(values []string) string {
+	quoted := "["
+	for i, v := range values {
+		if i > 0 {
+			quoted += ", "
+		}
+		quoted += fmt.Sprintf("%q", v)
+	}
+
+	return quoted + "]"
+}
@@ -0,0 +1,215 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBatchWindow is the default time the client waits to coalesce concurrent catalog operations.
+	DefaultBatchWindow = 20 * time.Millisecond
+
+	// The most operations of one kind sent to Square in a single batch request.
+	maxBatchSize = 100
+)
+
+// batcher coalesces concurrent single-object catalog operations into batch requests. Terraform
+// runs resource operations in parallel, so the retrieves, upserts, and deletes issued within a
+// short window of each other are sent to Square as one BatchRetrieveCatalogObjects,
+// BatchUpsertCatalogObjects, or BatchDeleteCatalogObjects call instead of one request each.
+type batcher struct {
+	retrieves *batchQueue
+	upserts   *batchQueue
+	deletes   *batchQueue
+}
+
+func newBatcher(c *Client, window time.Duration) *batcher {
+	return &batcher{
+		retrieves: &batchQueue{window: window, flush: c.flushRetrieves},
+		upserts:   &batchQueue{window: window, flush: c.flushUpserts},
+		deletes:   &batchQueue{window: window, flush: c.flushDeletes},
+	}
+}
+
+// batchOp is a single operation waiting in a batchQueue for its result.
+type batchOp struct {
+	in   interface{}
+	out  interface{}
+	err  error
+	done chan struct{}
+}
+
+func (op *batchOp) complete(out interface{}, err error) {
+	op.out, op.err = out, err
+	close(op.done)
+}
+
+// batchQueue collects operations of one kind until its window elapses or it fills up, then
+// hands them all to flush, which must complete every operation it is given.
+type batchQueue struct {
+	window time.Duration
+	flush  func([]*batchOp)
+
+	mu      sync.Mutex
+	pending []*batchOp
+	timer   *time.Timer
+}
+
+// Queues an operation and blocks until the batch containing it has been sent.
+func (q *batchQueue) do(in interface{}) (interface{}, error) {
+	op := &batchOp{in: in, done: make(chan struct{})}
+
+	q.mu.Lock()
+	q.pending = append(q.pending, op)
+	if len(q.pending) >= maxBatchSize {
+		ops := q.take()
+		q.mu.Unlock()
+		go q.flush(ops)
+	} else {
+		if q.timer == nil {
+			q.timer = time.AfterFunc(q.window, q.flushPending)
+		}
+		q.mu.Unlock()
+	}
+
+	<-op.done
+	return op.out, op.err
+}
+
+func (q *batchQueue) flushPending() {
+	q.mu.Lock()
+	ops := q.take()
+	q.mu.Unlock()
+
+	if len(ops) > 0 {
+		q.flush(ops)
+	}
+}
+
+// Removes and returns all pending operations. The caller must hold q.mu.
+func (q *batchQueue) take() []*batchOp {
+	if q.timer != nil {
+		q.timer.Stop()
+		q.timer = nil
+	}
+
+	ops := q.pending
+	q.pending = nil
+	return ops
+}
+
+func (c *Client) flushRetrieves(ops []*batchOp) {
+	if len(ops) == 1 {
+		ops[0].complete(c.retrieveCatalogObject(ops[0].in.(string)))
+		return
+	}
+
+	seen := map[string]bool{}
+	ids := []string{}
+	for _, op := range ops {
+		if id := op.in.(string); !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	objs, err := c.BatchRetrieveCatalogObjects(ids)
+	if err != nil {
+		// Retrieval is side-effect free, so fall back to individual requests to give each
+		// caller the error that applies to its own object.
+		for _, op := range ops {
+			op.complete(c.retrieveCatalogObject(op.in.(string)))
+		}
+		return
+	}
+
+	retrieved := map[string]*CatalogObject{}
+	for _, obj := range objs {
+		retrieved[StringValue(obj.ID)] = obj
+	}
+
+	for _, op := range ops {
+		id := op.in.(string)
+		if obj, ok := retrieved[id]; ok && !obj.IsDeleted {
+			op.complete(obj, nil)
+		} else {
+			op.complete(nil, fmt.Errorf("catalog object %s: %w", id, ErrNotFound))
+		}
+	}
+}
+
+func (c *Client) flushUpserts(ops []*batchOp) {
+	if len(ops) == 1 {
+		ops[0].complete(c.upsertCatalogObject(ops[0].in.(*CatalogObject)))
+		return
+	}
+
+	objs := make([]*CatalogObject, len(ops))
+	for i, op := range ops {
+		objs[i] = op.in.(*CatalogObject)
+	}
+
+	upserted, err := c.BatchUpsertCatalogObjects(objs)
+	if err != nil {
+		// A batch is applied atomically, so when Square rejects it outright nothing was written
+		// and each object can safely be upserted on its own to find the one at fault. Any other
+		// failure leaves the outcome unknown, so it is reported to every caller instead.
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode < http.StatusInternalServerError {
+			for _, op := range ops {
+				op.complete(c.upsertCatalogObject(op.in.(*CatalogObject)))
+			}
+			return
+		}
+
+		for _, op := range ops {
+			op.complete(nil, err)
+		}
+		return
+	}
+
+	for i, op := range ops {
+		op.complete(upserted[i], nil)
+	}
+}
+
+func (c *Client) flushDeletes(ops []*batchOp) {
+	if len(ops) == 1 {
+		ops[0].complete(c.deleteCatalogObject(ops[0].in.(string)))
+		return
+	}
+
+	ids := make([]string, len(ops))
+	for i, op := range ops {
+		ids[i] = op.in.(string)
+	}
+
+	deletedIDs, err := c.BatchDeleteCatalogObjects(ids)
+	if err != nil {
+		// Deletion is idempotent, so fall back to individual requests to give each caller the
+		// error that applies to its own object.
+		for _, op := range ops {
+			op.complete(c.deleteCatalogObject(op.in.(string)))
+		}
+		return
+	}
+
+	deleted := map[string]bool{}
+	for _, id := range deletedIDs {
+		deleted[id] = true
+	}
+
+	// Square leaves objects it did not delete, such as those that no longer exist, out of the
+	// response without saying why, so each of them is deleted on its own to find out.
+	for _, op := range ops {
+		id := op.in.(string)
+		if deleted[id] {
+			op.complete([]string{id}, nil)
+		} else {
+			op.complete(c.deleteCatalogObject(id))
+		}
+	}
+}
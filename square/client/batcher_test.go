@@ -0,0 +1,251 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	squaremodel "github.com/jefflinse/square-connect/models"
+	"github.com/jefflinse/terraform-provider-square/square/client/squaretest"
+)
+
+// The window is long enough for every operation a test starts together to be batched together.
+const testBatchWindow = 100 * time.Millisecond
+
+func newBatchingClient(t *testing.T, server *squaretest.Server) *Client {
+	c, err := NewClient(Config{
+		AccessToken: "token",
+		BaseURL:     server.URL,
+		BatchWindow: testBatchWindow,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func newTestCategory(name string) *CatalogObject {
+	return NewCatalogObject(&squaremodel.CatalogObject{
+		Type:         strPtr("CATEGORY"),
+		CategoryData: &squaremodel.CatalogCategory{Name: name},
+	})
+}
+
+// Runs fn concurrently for 0 through n-1 and waits for every call to return.
+func concurrently(n int, fn func(i int)) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// Counts the requests the server received since the specified number of requests.
+func countRequests(server *squaretest.Server, since int) map[string]int {
+	counts := map[string]int{}
+	for _, r := range server.Requests()[since:] {
+		counts[r]++
+	}
+
+	return counts
+}
+
+func TestBatcher_coalesces(t *testing.T) {
+	server := squaretest.NewServer()
+	defer server.Close()
+	c := newBatchingClient(t, server)
+
+	ids := make([]string, 5)
+	errs := make([]error, 5)
+	concurrently(5, func(i int) {
+		created, err := c.UpsertCatalogObject(newTestCategory(fmt.Sprintf("Category %d", i)))
+		if err == nil {
+			ids[i] = *created.ID
+		}
+		errs[i] = err
+	})
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	counts := countRequests(server, 0)
+	if counts["POST /v2/catalog/batch-upsert"] != 1 || counts["POST /v2/catalog/object"] != 0 {
+		t.Errorf("upserts were sent as %v, want a single batch", counts)
+	}
+
+	before := len(server.Requests())
+	retrieved := make([]*CatalogObject, 10)
+	retrieveErrs := make([]error, 10)
+	concurrently(10, func(i int) {
+		// Every object is retrieved twice, which the batch asks for once.
+		retrieved[i], retrieveErrs[i] = c.RetrieveCatalogObject(ids[i%5])
+	})
+	for _, err := range retrieveErrs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, obj := range retrieved {
+		if *obj.ID != ids[i%5] {
+			t.Errorf("retrieve %d got %s, want %s", i, *obj.ID, ids[i%5])
+		}
+	}
+
+	if counts := countRequests(server, before); len(counts) != 1 || counts["POST /v2/catalog/batch-retrieve"] != 1 {
+		t.Errorf("retrieves were sent as %v, want a single batch", counts)
+	}
+
+	before = len(server.Requests())
+	concurrently(5, func(i int) {
+		_, errs[i] = c.DeleteCatalogObject(ids[i])
+	})
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if counts := countRequests(server, before); len(counts) != 1 || counts["POST /v2/catalog/batch-delete"] != 1 {
+		t.Errorf("deletes were sent as %v, want a single batch", counts)
+	}
+}
+
+func TestBatcher_upsertFallback(t *testing.T) {
+	server := squaretest.NewServer()
+	defer server.Close()
+	c := newBatchingClient(t, server)
+
+	objs := []*CatalogObject{
+		newTestCategory("Apparel"),
+		// Square rejects the whole batch because of this variation of an item that does not exist.
+		NewCatalogObject(&squaremodel.CatalogObject{
+			Type: strPtr("ITEM_VARIATION"),
+			ItemVariationData: &squaremodel.CatalogItemVariation{
+				ItemID:      "MISSING",
+				Name:        "Large",
+				PricingType: "VARIABLE_PRICING",
+			},
+		}),
+		newTestCategory("Accessories"),
+	}
+
+	upserted := make([]*CatalogObject, len(objs))
+	errs := make([]error, len(objs))
+	concurrently(len(objs), func(i int) {
+		upserted[i], errs[i] = c.UpsertCatalogObject(objs[i])
+	})
+
+	var apiErr *APIError
+	if !errors.As(errs[1], &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("upserting the invalid variation returned %v, want a 400", errs[1])
+	}
+
+	for _, i := range []int{0, 2} {
+		if errs[i] != nil {
+			t.Errorf("upserting %s returned %v, want it upserted on its own", objs[i].CategoryData.Name, errs[i])
+		} else if _, ok := server.Object(*upserted[i].ID); !ok {
+			t.Errorf("category %s was not created", objs[i].CategoryData.Name)
+		}
+	}
+
+	counts := countRequests(server, 0)
+	if counts["POST /v2/catalog/batch-upsert"] != 1 || counts["POST /v2/catalog/object"] != 3 {
+		t.Errorf("upserts were sent as %v, want a batch followed by each on its own", counts)
+	}
+}
+
+func TestBatcher_upsertServerError(t *testing.T) {
+	server := squaretest.NewServer()
+	defer server.Close()
+
+	c, err := NewClient(Config{
+		AccessToken: "token",
+		BaseURL:     server.URL,
+		BatchWindow: testBatchWindow,
+		MaxRetries:  0,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The outcome of a batch that failed on Square's side is unknown, so nothing is resent.
+	server.FailNext(http.StatusInternalServerError, 1)
+	errs := make([]error, 2)
+	concurrently(2, func(i int) {
+		_, errs[i] = c.UpsertCatalogObject(newTestCategory(fmt.Sprintf("Category %d", i)))
+	})
+
+	for i, err := range errs {
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+			t.Errorf("upsert %d returned %v, want the batch's 500", i, err)
+		}
+	}
+
+	if counts := countRequests(server, 0); len(counts) != 1 || counts["POST /v2/catalog/batch-upsert"] != 1 {
+		t.Errorf("upserts were sent as %v, want only the batch", counts)
+	}
+}
+
+func TestBatcher_partialDelete(t *testing.T) {
+	server := squaretest.NewServer()
+	defer server.Close()
+	c := newBatchingClient(t, server)
+
+	created := make([]*CatalogObject, 2)
+	for i := range created {
+		var err error
+		if created[i], err = c.UpsertCatalogObject(newTestCategory(fmt.Sprintf("Category %d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ids := []string{*created[0].ID, "MISSING"}
+	deleted := make([][]string, len(ids))
+	errs := make([]error, len(ids))
+	concurrently(len(ids), func(i int) {
+		deleted[i], errs[i] = c.DeleteCatalogObject(ids[i])
+	})
+
+	if errs[0] != nil || len(deleted[0]) != 1 || deleted[0][0] != ids[0] {
+		t.Errorf("deleting %s returned %v, %v; want it deleted", ids[0], deleted[0], errs[0])
+	}
+	if !errors.Is(errs[1], ErrNotFound) {
+		t.Errorf("deleting an object that does not exist returned %v, %v; want ErrNotFound", deleted[1], errs[1])
+	}
+
+	// An object Square leaves out of a batch's response for no reason is deleted on its own.
+	server.Handle("POST /v2/catalog/batch-delete", func(w http.ResponseWriter, r *http.Request) {
+		squaretest.WriteJSON(w, http.StatusOK, map[string]interface{}{"deleted_object_ids": []string{}})
+	})
+
+	before := len(server.Requests())
+	ids = []string{*created[1].ID, "MISSING"}
+	concurrently(len(ids), func(i int) {
+		deleted[i], errs[i] = c.DeleteCatalogObject(ids[i])
+	})
+
+	if errs[0] != nil || len(deleted[0]) != 1 || deleted[0][0] != ids[0] {
+		t.Errorf("deleting %s returned %v, %v; want it deleted", ids[0], deleted[0], errs[0])
+	}
+	if _, ok := server.Object(ids[0]); ok {
+		t.Errorf("category %s was not deleted", ids[0])
+	}
+	if !errors.Is(errs[1], ErrNotFound) {
+		t.Errorf("deleting an object that does not exist returned %v, %v; want ErrNotFound", deleted[1], errs[1])
+	}
+
+	if counts := countRequests(server, before); counts["POST /v2/catalog/batch-delete"] != 1 || counts["DELETE /v2/catalog/object/"+ids[0]] != 1 {
+		t.Errorf("deletes were sent as %v, want a batch followed by each unconfirmed delete on its own", counts)
+	}
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"log"
+	"sync"
+)
+
+// catalogCache is a read-through cache of catalog objects. The first lookup loads the entire
+// catalog with one paginated listing; objects are then served from memory until a write
+// invalidates them. Lookups that miss fall through to Square (and are batched like any other
+// retrieval), and their results are cached in turn.
+//
+// An object nested in another, such as an item's variation, is also cached as part of its parent,
+// so writing the nested object invalidates its parent too. Every invalidation starts a new
+// generation; objects read from Square before it may predate the write and are not cached.
+type catalogCache struct {
+	list func(types ...string) ([]*CatalogObject, error)
+
+	once       sync.Once
+	mu         sync.RWMutex
+	objects    map[string]*CatalogObject
+	parents    map[string]string
+	generation uint64
+}
+
+func newCatalogCache(list func(types ...string) ([]*CatalogObject, error)) *catalogCache {
+	return &catalogCache{
+		list:    list,
+		objects: map[string]*CatalogObject{},
+		parents: map[string]string{},
+	}
+}
+
+// Returns the cached object with the specified ID, prefetching the catalog on first use.
+func (cc *catalogCache) get(id string) (*CatalogObject, bool) {
+	cc.once.Do(cc.prefetch)
+
+	cc.mu.RLock()
+	defer cc.mu.RUnlock()
+	obj, ok := cc.objects[id]
+	return obj, ok
+}
+
+// Returns the current generation, which must be read before retrieving objects to be put.
+func (cc *catalogCache) currentGeneration() uint64 {
+	cc.mu.RLock()
+	defer cc.mu.RUnlock()
+	return cc.generation
+}
+
+// Caches objects retrieved during the specified generation, and the objects nested inside them,
+// keeping whichever copy has the newer version. Nothing is cached if the cache has been
+// invalidated since, as the objects may have been retrieved before the write that invalidated it.
+func (cc *catalogCache) put(generation uint64, objs ...*CatalogObject) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if generation != cc.generation {
+		log.Printf("[DEBUG] not caching %d catalog objects retrieved before the cache was invalidated", len(objs))
+		return
+	}
+
+	for _, obj := range objs {
+		forEachCatalogObject(obj, func(o *CatalogObject) {
+			id := StringValue(o.ID)
+			if cached, ok := cc.objects[id]; !ok || cached.Version <= o.Version {
+				cc.objects[id] = o
+			}
+			if parent := parentID(o); parent != "" {
+				cc.parents[id] = parent
+			}
+			for _, child := range o.Children() {
+				cc.parents[StringValue(child.ID)] = id
+			}
+		})
+	}
+}
+
+// Drops an upserted object, any objects nested inside it, and the objects it is nested in from
+// the cache.
+func (cc *catalogCache) invalidate(obj *CatalogObject) {
+	ids := []string{}
+	forEachCatalogObject(obj, func(o *CatalogObject) {
+		ids = append(ids, StringValue(o.ID))
+		if parent := parentID(o); parent != "" {
+			ids = append(ids, parent)
+		}
+	})
+
+	cc.evict(ids...)
+}
+
+// Drops the objects with the specified IDs, and the objects they are nested in, from the cache.
+func (cc *catalogCache) evict(ids ...string) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	cc.generation++
+	for _, id := range ids {
+		for id != "" {
+			delete(cc.objects, id)
+			id = cc.parents[id]
+		}
+	}
+}
+
+func (cc *catalogCache) prefetch() {
+	generation := cc.currentGeneration()
+	objs, err := cc.list()
+	if err != nil {
+		log.Printf("[WARN] failed to prefetch the Square catalog, objects will be retrieved individually: %s", err)
+		return
+	}
+
+	log.Printf("[DEBUG] prefetched %d catalog objects", len(objs))
+	cc.put(generation, objs...)
+}
+
+// Calls fn for obj and for every catalog object nested inside it (item variations, modifiers,
+// and item option values).
+func forEachCatalogObject(obj *CatalogObject, fn func(*CatalogObject)) {
+	if obj == nil || obj.ID == nil {
+		return
+	}
+
+	fn(obj)
+
+	for _, child := range obj.Children() {
+		forEachCatalogObject(child, fn)
+	}
+}
+
+// Returns the ID of the object a catalog object is nested in, if it is one that can be.
+func parentID(obj *CatalogObject) string {
+	switch {
+	case obj.ItemVariationData != nil:
+		return obj.ItemVariationData.ItemID
+	case obj.ModifierData != nil:
+		return obj.ModifierData.ModifierListID
+	case obj.ItemOptionValueData != nil:
+		return obj.ItemOptionValueData.ItemOptionID
+	}
+
+	return ""
+}
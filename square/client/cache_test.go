@@ -0,0 +1,162 @@
+package client
+
+import (
+	"testing"
+
+	squaremodel "github.com/jefflinse/square-connect/models"
+	"github.com/jefflinse/terraform-provider-square/square/client/squaretest"
+)
+
+func newCachingClient(t *testing.T, server *squaretest.Server) *Client {
+	c, err := NewClient(Config{
+		AccessToken:     "token",
+		BaseURL:         server.URL,
+		PrefetchCatalog: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+// Creates an item with one variation, returning the item and its variation.
+func createTestItem(t *testing.T, c *Client) (*CatalogObject, *CatalogObject) {
+	item := NewCatalogObject(&squaremodel.CatalogObject{
+		Type:     strPtr("ITEM"),
+		ItemData: &squaremodel.CatalogItem{Name: "Smoked turkey"},
+	})
+	item.SetChildren([]*CatalogObject{NewCatalogObject(&squaremodel.CatalogObject{
+		Type:              strPtr("ITEM_VARIATION"),
+		ItemVariationData: &squaremodel.CatalogItemVariation{Name: "By the pound", PricingType: "VARIABLE_PRICING"},
+	})})
+
+	created, err := c.UpsertCatalogObject(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return created, created.Children()[0]
+}
+
+func TestCatalogCache_prefetch(t *testing.T) {
+	server := squaretest.NewServer()
+	defer server.Close()
+
+	item, variation := createTestItem(t, newTestClient(t, server))
+
+	c := newCachingClient(t, server)
+	before := len(server.Requests())
+	for _, id := range []string{*item.ID, *variation.ID, *item.ID} {
+		obj, err := c.RetrieveCatalogObject(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *obj.ID != id {
+			t.Errorf("retrieved %s, want %s", *obj.ID, id)
+		}
+	}
+
+	if got := server.Requests()[before:]; len(got) != 1 || got[0] != "GET /v2/catalog/list" {
+		t.Errorf("retrieves sent %v, want only the listing that prefetches the catalog", got)
+	}
+}
+
+func TestCatalogCache_invalidatesParents(t *testing.T) {
+	server := squaretest.NewServer()
+	defer server.Close()
+
+	c := newCachingClient(t, server)
+	item, variation := createTestItem(t, c)
+
+	if _, err := c.RetrieveCatalogObject(*item.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	variation.ItemVariationData.Name = "By the slice"
+	if _, err := c.UpsertCatalogObject(variation); err != nil {
+		t.Fatal(err)
+	}
+
+	retrieved, err := c.RetrieveCatalogObject(*item.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := retrieved.ItemData.Variations[0].ItemVariationData.Name; name != "By the slice" {
+		t.Errorf("item's variation is named %q after the variation was updated, want %q", name, "By the slice")
+	}
+
+	added, err := c.UpsertCatalogObject(NewCatalogObject(&squaremodel.CatalogObject{
+		Type: strPtr("ITEM_VARIATION"),
+		ItemVariationData: &squaremodel.CatalogItemVariation{
+			ItemID:      *item.ID,
+			Name:        "By the tray",
+			PricingType: "VARIABLE_PRICING",
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if retrieved, err = c.RetrieveCatalogObject(*item.ID); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(retrieved.ItemData.Variations); n != 2 {
+		t.Errorf("item has %d variations after one was added, want 2", n)
+	}
+
+	if _, err := c.DeleteCatalogObject(*added.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if retrieved, err = c.RetrieveCatalogObject(*item.ID); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(retrieved.ItemData.Variations); n != 1 {
+		t.Errorf("item has %d variations after one was deleted, want 1", n)
+	}
+}
+
+func TestCatalogCache_stalePut(t *testing.T) {
+	cc := newCatalogCache(func(...string) ([]*CatalogObject, error) {
+		return nil, nil
+	})
+
+	obj := NewCatalogObject(&squaremodel.CatalogObject{
+		ID:           strPtr("CATEGORY"),
+		Type:         strPtr("CATEGORY"),
+		Version:      1,
+		CategoryData: &squaremodel.CatalogCategory{Name: "Apparel"},
+	})
+
+	// The object is retrieved, then updated and evicted before the retrieved copy is cached.
+	generation := cc.currentGeneration()
+	cc.evict("CATEGORY")
+	cc.put(generation, obj)
+
+	if cached, ok := cc.get("CATEGORY"); ok {
+		t.Errorf("cached %v retrieved before the cache was invalidated", cached)
+	}
+
+	cc.put(cc.currentGeneration(), obj)
+	if _, ok := cc.get("CATEGORY"); !ok {
+		t.Error("object retrieved after the cache was invalidated was not cached")
+	}
+}
+
+func TestCatalogCache_stalePrefetch(t *testing.T) {
+	var cc *catalogCache
+	cc = newCatalogCache(func(...string) ([]*CatalogObject, error) {
+		// The catalog changes while it is being listed.
+		cc.evict("CATEGORY")
+		return []*CatalogObject{NewCatalogObject(&squaremodel.CatalogObject{
+			ID:           strPtr("CATEGORY"),
+			Type:         strPtr("CATEGORY"),
+			CategoryData: &squaremodel.CatalogCategory{Name: "Apparel"},
+		})}, nil
+	})
+
+	if cached, ok := cc.get("CATEGORY"); ok {
+		t.Errorf("cached %v listed before the cache was invalidated", cached)
+	}
+}
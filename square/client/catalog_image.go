@@ -0,0 +1,117 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	runtime "github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+)
+
+// CreateCatalogImage uploads an image file and creates the IMAGE catalog object describing it.
+// If objectID is not empty, the image is attached to the catalog object with that ID. An image
+// without an ID is given a random temporary ID, like the objects created by UpsertCatalogObject.
+func (c *Client) CreateCatalogImage(objectID string, image *CatalogObject, filename string, content io.Reader) (*CatalogObject, error) {
+	file, err := ioutil.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if StringValue(image.ID) == "" {
+		image.ID = newTempID()
+	}
+
+	return c.uploadCatalogImage(objectID, image, filename, file)
+}
+
+func (c *Client) uploadCatalogImage(objectID string, image *CatalogObject, filename string, file []byte) (*CatalogObject, error) {
+	key, err := idempotencyKeyFor(catalogImageUpload(objectID, image, file))
+	if err != nil {
+		return nil, err
+	}
+
+	// The request is a form field rather than the body, so it is written here.
+	request, err := json.Marshal(&struct {
+		IdempotencyKey *string        `json:"idempotency_key"`
+		ObjectID       string         `json:"object_id,omitempty"`
+		Image          *CatalogObject `json:"image"`
+	}{key, objectID, image})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.transport.Submit(&runtime.ClientOperation{
+		ID:                 "CreateCatalogImage",
+		Method:             http.MethodPost,
+		PathPattern:        "/v2/catalog/images",
+		ProducesMediaTypes: []string{runtime.JSONMime},
+		ConsumesMediaTypes: []string{runtime.MultipartFormMime},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			if err := r.SetFormParam("request", string(request)); err != nil {
+				return err
+			}
+
+			return r.SetFileParam("image_file", &namedFile{Reader: bytes.NewReader(file), name: filename})
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(resp runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			if resp.Code() != http.StatusOK {
+				return nil, runtime.NewAPIError("CreateCatalogImage", resp, resp.Code())
+			}
+
+			payload := &createCatalogImageResponse{}
+			if err := consumer.Consume(resp.Body(), payload); err != nil && err != io.EOF {
+				return nil, err
+			}
+
+			return payload, nil
+		}),
+		AuthInfo: c.auth(),
+		Context:  context.Background(),
+	})
+	if err != nil {
+		return nil, translateError("CreateCatalogImage", err)
+	}
+
+	if c.cache != nil && objectID != "" {
+		c.cache.evict(objectID)
+	}
+
+	return result.(*createCatalogImageResponse).Image, nil
+}
+
+// Returns what an image upload writes. The file is part of it, so it is part of the idempotency
+// key too.
+func catalogImageUpload(objectID string, image *CatalogObject, file []byte) interface{} {
+	sum := sha256.Sum256(file)
+	return struct {
+		ObjectID string         `json:"object_id"`
+		Image    *CatalogObject `json:"image"`
+		File     string         `json:"file"`
+	}{objectID, image, hex.EncodeToString(sum[:])}
+}
+
+type createCatalogImageResponse struct {
+	Image *CatalogObject `json:"image"`
+}
+
+// namedFile is an in-memory file part of a multipart request.
+type namedFile struct {
+	*bytes.Reader
+	name string
+}
+
+// Name implements runtime.NamedReadCloser.
+func (f *namedFile) Name() string {
+	return f.name
+}
+
+// Close implements runtime.NamedReadCloser.
+func (f *namedFile) Close() error {
+	return nil
+}
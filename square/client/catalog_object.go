@@ -1,43 +1,348 @@
 package client
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	runtime "github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
 	catalogAPI "github.com/jefflinse/square-connect/client/catalog"
 	squaremodel "github.com/jefflinse/square-connect/models"
 )
 
 // RetrieveCatalogObject retrieves a Square CatalogObject.
-func (c *Client) RetrieveCatalogObject(id string) (*squaremodel.CatalogObject, error) {
-	params := catalogAPI.NewRetrieveCatalogObjectParams().WithObjectID(id)
-	resp, err := c.square.Catalog.RetrieveCatalogObject(params, c.auth())
+func (c *Client) RetrieveCatalogObject(id string) (*CatalogObject, error) {
+	var generation uint64
+	if c.cache != nil {
+		if obj, ok := c.cache.get(id); ok {
+			return obj, nil
+		}
+		generation = c.cache.currentGeneration()
+	}
+
+	var obj *CatalogObject
+	if c.batcher != nil {
+		out, err := c.batcher.retrieves.do(id)
+		if err != nil {
+			return nil, err
+		}
+		obj = out.(*CatalogObject)
+	} else {
+		var err error
+		if obj, err = c.retrieveCatalogObject(id); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.cache != nil {
+		c.cache.put(generation, obj)
+	}
+
+	return obj, nil
+}
+
+// UpsertCatalogObject creates or updates a Square CatalogObject. An object is created if its ID
+// is empty or a temporary ID ("#" followed by a key unique to the object being created); an object
+// without an ID is given a random temporary ID. Objects nested in obj without IDs are given
+// temporary IDs derived from obj, so that retrying the same upsert repeats the same write. An
+// update is rejected with a *ConflictError if the object has been modified since obj.Version,
+// unless the client was configured with ConflictPolicyRetry.
+func (c *Client) UpsertCatalogObject(obj *CatalogObject) (*CatalogObject, error) {
+	if StringValue(obj.ID) == "" {
+		obj.ID = newTempID()
+	}
+
+	if ids := newObjectIDs(obj); len(ids) > 0 {
+		key, err := idempotencyKeyFor(obj)
+		if err != nil {
+			return nil, err
+		}
+		setTempIDs(ids, *key)
+	}
+
+	upserted, err := c.upsert(obj)
+	if isVersionMismatch(err) && !isTempID(StringValue(obj.ID)) {
+		return c.resolveConflict(obj, err)
+	}
+
+	return upserted, err
+}
+
+// Returns a random temporary ID for an object created without one.
+func newTempID() *string {
+	return strPtr("#" + uuid.New().String())
+}
+
+// Reports whether an ID is a temporary ID, which identifies an object that is yet to be created.
+func isTempID(id string) bool {
+	return strings.HasPrefix(id, "#")
+}
+
+// Returns the ID fields of a catalog object and the objects nested in it that are empty because
+// the objects are new.
+func newObjectIDs(obj *CatalogObject) []**string {
+	ids := []**string{}
+	for _, o := range append([]*CatalogObject{obj}, obj.Children()...) {
+		if StringValue(o.ID) == "" {
+			ids = append(ids, &o.ID)
+		}
+	}
+
+	return ids
+}
+
+// Sets ID fields to temporary IDs derived from seed, which must identify the write they are part of.
+func setTempIDs(ids []**string, seed string) {
+	base := uuid.NewSHA1(idempotencyNamespace, []byte(seed))
+	for i, id := range ids {
+		*id = strPtr(fmt.Sprintf("#%s-%d", base, i))
+	}
+}
+
+// Upserts an object through the batcher and cache, if enabled.
+func (c *Client) upsert(obj *CatalogObject) (*CatalogObject, error) {
+	var upserted *CatalogObject
+	if c.batcher != nil {
+		out, err := c.batcher.upserts.do(obj)
+		if err != nil {
+			return nil, err
+		}
+		upserted = out.(*CatalogObject)
+	} else {
+		var err error
+		if upserted, err = c.upsertCatalogObject(obj); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.cache != nil {
+		c.cache.invalidate(upserted)
+	}
+
+	return upserted, nil
+}
+
+// DeleteCatalogObject deletes a Square CatalogObject with the specified ID.
+func (c *Client) DeleteCatalogObject(id string) ([]string, error) {
+	var deleted []string
+	if c.batcher != nil {
+		out, err := c.batcher.deletes.do(id)
+		if err != nil {
+			return nil, err
+		}
+		deleted = out.([]string)
+	} else {
+		var err error
+		if deleted, err = c.deleteCatalogObject(id); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.cache != nil {
+		c.cache.evict(append(deleted, id)...)
+	}
+
+	return deleted, nil
+}
+
+// ListCatalog lists every Square CatalogObject of the specified types, following pagination
+// cursors until the whole catalog has been read. All top-level types are listed if none are given.
+func (c *Client) ListCatalog(types ...string) ([]*CatalogObject, error) {
+	objs := []*CatalogObject{}
+	cursor := ""
+	for {
+		var resp struct {
+			Cursor  string           `json:"cursor"`
+			Objects []*CatalogObject `json:"objects"`
+		}
+		if err := c.submitCatalog("ListCatalog", http.MethodGet, "/v2/catalog/list", func(r runtime.ClientRequest) error {
+			if len(types) > 0 {
+				if err := r.SetQueryParam("types", strings.Join(types, ",")); err != nil {
+					return err
+				}
+			}
+			if cursor != "" {
+				return r.SetQueryParam("cursor", cursor)
+			}
+			return nil
+		}, &resp); err != nil {
+			return nil, err
+		}
+
+		objs = append(objs, resp.Objects...)
+		if resp.Cursor == "" {
+			return objs, nil
+		}
+
+		cursor = resp.Cursor
+	}
+}
+
+// BatchRetrieveCatalogObjects retrieves the Square CatalogObjects with the specified IDs in a single request.
+// Objects that do not exist are omitted from the result.
+func (c *Client) BatchRetrieveCatalogObjects(ids []string) ([]*CatalogObject, error) {
+	var resp struct {
+		Objects []*CatalogObject `json:"objects"`
+	}
+	if err := c.submitCatalog("BatchRetrieveCatalogObjects", http.MethodPost, "/v2/catalog/batch-retrieve", bodyParam(&squaremodel.BatchRetrieveCatalogObjectsRequest{
+		ObjectIds: ids,
+	}), &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Objects, nil
+}
+
+// catalogObjectBatch is a batch of catalog objects upserted together.
+type catalogObjectBatch struct {
+	Objects []*CatalogObject `json:"objects"`
+}
+
+// BatchUpsertCatalogObjects atomically creates or updates the specified Square CatalogObjects in a single
+// request. The returned objects are in the same order as objs.
+func (c *Client) BatchUpsertCatalogObjects(objs []*CatalogObject) ([]*CatalogObject, error) {
+	batches := []*catalogObjectBatch{{Objects: objs}}
+	key, err := idempotencyKeyFor(batches)
 	if err != nil {
 		return nil, err
 	}
 
-	return resp.Payload.Object, nil
+	var resp struct {
+		IDMappings []*squaremodel.CatalogIDMapping `json:"id_mappings"`
+		Objects    []*CatalogObject                `json:"objects"`
+	}
+	if err := c.submitCatalog("BatchUpsertCatalogObjects", http.MethodPost, "/v2/catalog/batch-upsert", bodyParam(&struct {
+		IdempotencyKey *string               `json:"idempotency_key"`
+		Batches        []*catalogObjectBatch `json:"batches"`
+	}{key, batches}), &resp); err != nil {
+		return nil, err
+	}
+
+	ids := map[string]string{}
+	for _, mapping := range resp.IDMappings {
+		ids[mapping.ClientObjectID] = mapping.ObjectID
+	}
+
+	upserted := map[string]*CatalogObject{}
+	for _, obj := range resp.Objects {
+		upserted[StringValue(obj.ID)] = obj
+	}
+
+	result := make([]*CatalogObject, len(objs))
+	for i, obj := range objs {
+		id := StringValue(obj.ID)
+		if mapped, ok := ids[id]; ok {
+			id = mapped
+		}
+
+		created, ok := upserted[id]
+		if !ok {
+			return nil, fmt.Errorf("square: BatchUpsertCatalogObjects did not return catalog object %s", id)
+		}
+		result[i] = created
+	}
+
+	return result, nil
 }
 
-// UpsertCatalogObject creates or updates a Square CatalogObject.
-func (c *Client) UpsertCatalogObject(obj *squaremodel.CatalogObject) (*squaremodel.CatalogObject, error) {
-	params := catalogAPI.NewUpsertCatalogObjectParams().WithBody(&squaremodel.UpsertCatalogObjectRequest{
-		IdempotencyKey: newIdempotencyKey(),
-		Object:         obj,
+// BatchDeleteCatalogObjects deletes the Square CatalogObjects with the specified IDs in a single request,
+// returning the IDs of every object deleted, including children of the specified objects.
+func (c *Client) BatchDeleteCatalogObjects(ids []string) ([]string, error) {
+	params := catalogAPI.NewBatchDeleteCatalogObjectsParams().WithBody(&squaremodel.BatchDeleteCatalogObjectsRequest{
+		ObjectIds: ids,
 	})
 
-	resp, err := c.square.Catalog.UpsertCatalogObject(params, c.auth())
+	resp, err := c.square.Catalog.BatchDeleteCatalogObjects(params, c.auth())
+	if err != nil {
+		return nil, translateError("BatchDeleteCatalogObjects", err)
+	}
+
+	return resp.Payload.DeletedObjectIds, nil
+}
+
+func (c *Client) retrieveCatalogObject(id string) (*CatalogObject, error) {
+	var resp struct {
+		Object *CatalogObject `json:"object"`
+	}
+	if err := c.submitCatalog("RetrieveCatalogObject", http.MethodGet, "/v2/catalog/object/{object_id}", func(r runtime.ClientRequest) error {
+		return r.SetPathParam("object_id", id)
+	}, &resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Object == nil || resp.Object.IsDeleted {
+		return nil, fmt.Errorf("catalog object %s: %w", id, ErrNotFound)
+	}
+
+	return resp.Object, nil
+}
+
+func (c *Client) upsertCatalogObject(obj *CatalogObject) (*CatalogObject, error) {
+	key, err := idempotencyKeyFor(obj)
 	if err != nil {
 		return nil, err
 	}
 
-	return resp.Payload.CatalogObject, nil
+	var resp struct {
+		CatalogObject *CatalogObject `json:"catalog_object"`
+	}
+	if err := c.submitCatalog("UpsertCatalogObject", http.MethodPost, "/v2/catalog/object", bodyParam(&struct {
+		IdempotencyKey *string        `json:"idempotency_key"`
+		Object         *CatalogObject `json:"object"`
+	}{key, obj}), &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.CatalogObject, nil
 }
 
-// DeleteCatalogObject deletes a Square CatalogObject with the specified ID.
-func (c *Client) DeleteCatalogObject(id string) ([]string, error) {
+func (c *Client) deleteCatalogObject(id string) ([]string, error) {
 	params := catalogAPI.NewDeleteCatalogObjectParams().WithObjectID(id)
 	resp, err := c.square.Catalog.DeleteCatalogObject(params, c.auth())
 	if err != nil {
-		return nil, err
+		return nil, translateError("DeleteCatalogObject", err)
 	}
 
 	return resp.Payload.DeletedObjectIds, nil
 }
+
+// Submits a catalog operation with the transport rather than the generated client, whose models
+// cannot carry the Fields of a CatalogObject, and decodes Square's response into result.
+func (c *Client) submitCatalog(operation, method, path string, params func(runtime.ClientRequest) error, result interface{}) error {
+	_, err := c.transport.Submit(&runtime.ClientOperation{
+		ID:                 operation,
+		Method:             method,
+		PathPattern:        path,
+		ProducesMediaTypes: []string{runtime.JSONMime},
+		ConsumesMediaTypes: []string{runtime.JSONMime},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			return params(r)
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(resp runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			if resp.Code() != http.StatusOK {
+				return nil, runtime.NewAPIError(operation, resp, resp.Code())
+			}
+
+			if err := consumer.Consume(resp.Body(), result); err != nil && err != io.EOF {
+				return nil, err
+			}
+
+			return result, nil
+		}),
+		AuthInfo: c.auth(),
+		Context:  context.Background(),
+	})
+
+	return translateError(operation, err)
+}
+
+// Returns request parameters consisting of the specified body.
+func bodyParam(body interface{}) func(runtime.ClientRequest) error {
+	return func(r runtime.ClientRequest) error {
+		return r.SetBodyParam(body)
+	}
+}
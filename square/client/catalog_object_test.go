@@ -0,0 +1,138 @@
+package client
+
+import (
+	"testing"
+
+	squaremodel "github.com/jefflinse/square-connect/models"
+	"github.com/jefflinse/terraform-provider-square/square/client/squaretest"
+)
+
+func newTestClient(t *testing.T, server *squaretest.Server) *Client {
+	c, err := NewClient(Config{
+		AccessToken: "token",
+		BaseURL:     server.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func newTestItem() *CatalogObject {
+	item := NewCatalogObject(&squaremodel.CatalogObject{
+		Type:     strPtr("ITEM"),
+		ItemData: &squaremodel.CatalogItem{Name: "Smoked turkey"},
+	})
+	item.SetChildren([]*CatalogObject{
+		NewCatalogObject(&squaremodel.CatalogObject{
+			Type:              strPtr("ITEM_VARIATION"),
+			ItemVariationData: &squaremodel.CatalogItemVariation{Name: "By the pound", PricingType: "VARIABLE_PRICING"},
+		}),
+	})
+
+	return item
+}
+
+func TestUpsertCatalogObject_retriedCreate(t *testing.T) {
+	server := squaretest.NewServer()
+	defer server.Close()
+
+	// Each client stands in for an attempt to create the same object, the first of which timed
+	// out after Square created it.
+	first := newTestItem()
+	first.ID = strPtr("#key")
+	created, err := newTestClient(t, server).UpsertCatalogObject(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second := newTestItem()
+	second.ID = strPtr("#key")
+	retried, err := newTestClient(t, server).UpsertCatalogObject(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *retried.ID != *created.ID || *retried.ItemData.Variations[0].ID != *created.ItemData.Variations[0].ID {
+		t.Errorf("created %s and then %s, want the same item", *created.ID, *retried.ID)
+	}
+}
+
+func TestUpsertCatalogObject_separateRuns(t *testing.T) {
+	server := squaretest.NewServer()
+	defer server.Close()
+
+	// Each client stands in for a run of Terraform creating an identical object.
+	first, err := newTestClient(t, server).UpsertCatalogObject(newTestItem())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := newTestClient(t, server).UpsertCatalogObject(newTestItem())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *second.ID == *first.ID {
+		t.Errorf("created item %s twice, want two items", *first.ID)
+	}
+
+	objs, err := newTestClient(t, server).ListCatalog("ITEM")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objs) != 2 {
+		t.Errorf("catalog has %d items, want 2", len(objs))
+	}
+}
+
+func TestUpsertCatalogObject_identicalObjects(t *testing.T) {
+	server := squaretest.NewServer()
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	first, err := c.UpsertCatalogObject(newTestItem())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := c.UpsertCatalogObject(newTestItem())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *second.ID == *first.ID {
+		t.Errorf("created item %s twice, want two items", *first.ID)
+	}
+}
+
+func TestUpsertCatalogObject_newChildren(t *testing.T) {
+	server := squaretest.NewServer()
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	item, err := c.UpsertCatalogObject(newTestItem())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	children := item.Children()
+	children = append(children, NewCatalogObject(&squaremodel.CatalogObject{
+		Type:              strPtr("ITEM_VARIATION"),
+		ItemVariationData: &squaremodel.CatalogItemVariation{Name: "By the slice", PricingType: "VARIABLE_PRICING"},
+	}))
+	item.SetChildren(children)
+
+	updated, err := c.UpsertCatalogObject(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(updated.ItemData.Variations); n != 2 {
+		t.Fatalf("item has %d variations, want 2", n)
+	}
+	if *updated.ItemData.Variations[0].ID != *item.ItemData.Variations[0].ID {
+		t.Errorf("existing variation was replaced")
+	}
+}
@@ -1,52 +1,254 @@
 package client
 
 import (
-	"os"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
 
 	runtime "github.com/go-openapi/runtime"
 	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
 	"github.com/google/uuid"
 	squareclient "github.com/jefflinse/square-connect/client"
 	squaremodel "github.com/jefflinse/square-connect/models"
 )
 
 const (
-	squareAPIHost = "connect.squareupsandbox.com"
+	// EnvironmentProduction targets Square's production API.
+	EnvironmentProduction = "production"
+
+	// EnvironmentSandbox targets Square's sandbox API.
+	EnvironmentSandbox = "sandbox"
+
+	// DefaultSquareVersion is the Square API version the client models were generated against.
+	DefaultSquareVersion = "2020-09-23"
+
+	productionAPIHost = "connect.squareup.com"
+	sandboxAPIHost    = "connect.squareupsandbox.com"
 )
 
 // SquareAPI defines an interface for Square's REST API.
 type SquareAPI interface {
+	BatchChangeInventory(changes []*squaremodel.InventoryChange) ([]*squaremodel.InventoryCount, error)
+	BatchDeleteCatalogObjects(ids []string) ([]string, error)
+	BatchRetrieveCatalogObjects(ids []string) ([]*CatalogObject, error)
+	BatchUpsertCatalogObjects([]*CatalogObject) ([]*CatalogObject, error)
+	CreateCatalogImage(objectID string, image *CatalogObject, filename string, content io.Reader) (*CatalogObject, error)
 	DeleteCatalogObject(id string) ([]string, error)
-	RetrieveCatalogObject(id string) (*squaremodel.CatalogObject, error)
-	UpsertCatalogObject(*squaremodel.CatalogObject) (*squaremodel.CatalogObject, error)
+	ListCatalog(types ...string) ([]*CatalogObject, error)
+	ListLocations() ([]*squaremodel.Location, error)
+	RetrieveCatalogObject(id string) (*CatalogObject, error)
+	RetrieveInventoryCount(catalogObjectID, locationID, state string) (*squaremodel.InventoryCount, error)
+	UpsertCatalogObject(*CatalogObject) (*CatalogObject, error)
+}
+
+// Config holds the settings used to create a Client.
+type Config struct {
+	// AccessToken is the OAuth or personal access token used to authenticate requests.
+	AccessToken string
+
+	// Environment selects the Square API host; either EnvironmentSandbox or EnvironmentProduction.
+	Environment string
+
+	// BaseURL, if set, overrides the host selected by Environment (e.g. "http://localhost:8080").
+	BaseURL string
+
+	// SquareVersion is sent as the Square-Version header on every request.
+	SquareVersion string
+
+	// MaxRetries is the number of times a rate limited or transiently failing request is retried.
+	MaxRetries int
+
+	// MaxRetryWait caps the delay between two attempts of the same request.
+	MaxRetryWait time.Duration
+
+	// ConflictPolicy decides what happens when an update is rejected because the object was
+	// modified since it was last read; either ConflictPolicyFail (the default) or ConflictPolicyRetry.
+	ConflictPolicy string
+
+	// PrefetchCatalog loads the entire catalog on the first retrieval and serves later retrievals
+	// from memory, which makes refreshing configurations with many catalog objects much faster.
+	PrefetchCatalog bool
+
+	// LogBodyLimit is the number of bytes of each request and response body written to the TRACE
+	// log. Zero uses DefaultLogBodyLimit and a negative limit logs bodies in full.
+	LogBodyLimit int
+
+	// BatchWindow is how long single-object catalog operations are held so that concurrent ones
+	// can be coalesced into batch requests. Zero disables coalescing.
+	BatchWindow time.Duration
+
+	// Cassette, if set, is the path of a file that HTTP interactions with Square are recorded to
+	// or replayed from, depending on CassetteMode.
+	Cassette string
+
+	// CassetteMode is either CassetteModeRecord or CassetteModeReplay (the default).
+	CassetteMode string
 }
 
 // Client is the Square API client.
 type Client struct {
-	auth   func() runtime.ClientAuthInfoWriter
-	square *squareclient.SquareConnect
+	auth    func() runtime.ClientAuthInfoWriter
+	batcher *batcher
+	cache   *catalogCache
+	square  *squareclient.SquareConnect
+
+	// transport submits the operations the generated client lacks or cannot model, such as
+	// multipart uploads and the catalog operations carrying CatalogObject fields.
+	transport runtime.ClientTransport
+
+	conflictPolicy string
+
+	locationsMu sync.Mutex
+	locations   []*squaremodel.Location
 }
 
 var _ SquareAPI = &Client{}
 
-// NewClient creates a new Square API client using the specified auth token.
-func NewClient(token string) *Client {
-	transport := httptransport.New(squareAPIHost, squareclient.DefaultBasePath, squareclient.DefaultSchemes)
-	if os.Getenv("TERRAFORM_PROVIDER_SQUARE_DEBUG") != "" {
-		transport.Debug = true
+// NewClient creates a new Square API client using the specified configuration.
+func NewClient(cfg Config) (*Client, error) {
+	host, basePath, schemes, err := cfg.endpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.ConflictPolicy {
+	case "":
+		cfg.ConflictPolicy = ConflictPolicyFail
+	case ConflictPolicyFail, ConflictPolicyRetry:
+	default:
+		return nil, fmt.Errorf("unknown conflict policy '%s'", cfg.ConflictPolicy)
 	}
 
-	squareclient.Default.SetTransport(transport)
-	return &Client{
+	version := cfg.SquareVersion
+	if version == "" {
+		version = DefaultSquareVersion
+	}
+
+	// Each client owns its HTTP client and connection pool so that multiple configured
+	// providers (e.g. sandbox and production aliases) never share a transport or host.
+	logBodyLimit := cfg.LogBodyLimit
+	if logBodyLimit == 0 {
+		logBodyLimit = DefaultLogBodyLimit
+	}
+
+	// Every attempt of a retried request is logged separately, with its own Square-Request-Id.
+	var rt http.RoundTripper = &errorBodyTransport{
+		next: newRetryTransport(
+			newLoggingTransport(http.DefaultTransport.(*http.Transport).Clone(), cfg.AccessToken, logBodyLimit),
+			cfg.MaxRetries,
+			cfg.MaxRetryWait,
+		),
+	}
+
+	// The recorder sits outside the retry transport so that a cassette holds only the response
+	// each request finally got, and replaying it never waits on a backoff.
+	if cfg.Cassette != "" {
+		mode := cfg.CassetteMode
+		if mode == "" {
+			mode = CassetteModeReplay
+		}
+
+		rt, err = newRecorderTransport(rt, mode, cfg.Cassette)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	httpClient := &http.Client{Transport: rt}
+
+	transport := httptransport.NewWithClient(host, basePath, schemes, httpClient)
+
+	c := &Client{
 		auth: func() runtime.ClientAuthInfoWriter {
-			return httptransport.BearerToken(token)
+			return runtime.ClientAuthInfoWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+				if err := r.SetHeaderParam("Square-Version", version); err != nil {
+					return err
+				}
+
+				return httptransport.BearerToken(cfg.AccessToken).AuthenticateRequest(r, reg)
+			})
 		},
-		square: squareclient.Default,
+		square:         squareclient.New(transport, strfmt.Default),
+		transport:      transport,
+		conflictPolicy: cfg.ConflictPolicy,
+	}
+
+	if cfg.BatchWindow > 0 {
+		c.batcher = newBatcher(c, cfg.BatchWindow)
 	}
+
+	if cfg.PrefetchCatalog {
+		c.cache = newCatalogCache(c.ListCatalog)
+	}
+
+	return c, nil
 }
 
-// Generates a new idempotency key for a Square API request.
-func newIdempotencyKey() *string {
-	key := uuid.New().String()
-	return &key
+// Resolves the host, base path, and schemes the client should send requests to.
+func (cfg Config) endpoint() (string, string, []string, error) {
+	if cfg.BaseURL != "" {
+		u, err := url.Parse(cfg.BaseURL)
+		if err != nil {
+			return "", "", nil, fmt.Errorf("invalid base URL '%s': %w", cfg.BaseURL, err)
+		}
+
+		if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			return "", "", nil, fmt.Errorf("invalid base URL '%s': must be an absolute http or https URL", cfg.BaseURL)
+		}
+
+		basePath := strings.TrimSuffix(u.Path, "/")
+		if basePath == "" {
+			basePath = squareclient.DefaultBasePath
+		}
+
+		return u.Host, basePath, []string{u.Scheme}, nil
+	}
+
+	switch cfg.Environment {
+	case EnvironmentProduction:
+		return productionAPIHost, squareclient.DefaultBasePath, squareclient.DefaultSchemes, nil
+	case EnvironmentSandbox, "":
+		return sandboxAPIHost, squareclient.DefaultBasePath, squareclient.DefaultSchemes, nil
+	default:
+		return "", "", nil, fmt.Errorf("unknown environment '%s'", cfg.Environment)
+	}
+}
+
+// Returns a pointer to the specified string value.
+func strPtr(value string) *string {
+	return &value
+}
+
+// StringValue returns the value of a string pointer, such as the ID of a catalog object, or the
+// empty string if it is nil.
+func StringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+// idempotencyNamespace scopes the name-based UUIDs used as idempotency keys to this provider.
+var idempotencyNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com/jefflinse/terraform-provider-square"))
+
+// Derives the idempotency key for a write from the payload being written. A logical upsert is
+// identified by its object ID (the temporary ID for a create, which is unique to the object being
+// created, or the real ID and version for an update) together with its data, so every retry of
+// the same upsert presents the same key and converges on the same Square object, while a
+// genuinely different write always gets a fresh key.
+func idempotencyKeyFor(payload interface{}) (*string, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	key := uuid.NewSHA1(idempotencyNamespace, b).String()
+	return &key, nil
 }
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	squaremodel "github.com/jefflinse/square-connect/models"
+)
+
+const (
+	// ConflictPolicyFail rejects an update to an object that was modified since it was last read.
+	ConflictPolicyFail = "fail"
+
+	// ConflictPolicyRetry re-reads an object that was modified since it was last read and applies
+	// the update on top of the latest version, overwriting the concurrent changes.
+	ConflictPolicyRetry = "retry"
+
+	// The number of times an update is re-applied under ConflictPolicyRetry before giving up.
+	maxConflictRetries = 3
+
+	versionMismatchCode = "VERSION_MISMATCH"
+)
+
+// ConflictError is returned when Square rejects an update because the object was modified
+// (e.g. in the Square Dashboard) after the version being updated was read.
+type ConflictError struct {
+	// ID is the ID of the object that could not be updated.
+	ID string
+
+	// Version is the version of the object the update was based on.
+	Version int64
+
+	// Err is the error Square returned.
+	Err error
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("catalog object %s was modified outside of Terraform after version %d was read; "+
+		"refresh and review the changes before applying again (%s)", e.ID, e.Version, e.Err)
+}
+
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
+
+// Reports whether an error is Square rejecting a write because of a version mismatch.
+func isVersionMismatch(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.HasCode(versionMismatchCode)
+}
+
+// Handles an update rejected because of a version mismatch according to the client's conflict policy.
+func (c *Client) resolveConflict(obj *CatalogObject, err error) (*CatalogObject, error) {
+	id := StringValue(obj.ID)
+	if c.conflictPolicy != ConflictPolicyRetry {
+		return nil, &ConflictError{ID: id, Version: obj.Version, Err: err}
+	}
+
+	for attempt := 1; attempt <= maxConflictRetries; attempt++ {
+		if c.cache != nil {
+			c.cache.evict(id)
+		}
+
+		latest, retrieveErr := c.RetrieveCatalogObject(id)
+		if retrieveErr != nil {
+			return nil, retrieveErr
+		}
+
+		log.Printf("[WARN] catalog object %s changed from version %d to %d since it was read; reapplying the update (attempt %d of %d)",
+			id, obj.Version, latest.Version, attempt, maxConflictRetries)
+
+		var upserted *CatalogObject
+		if upserted, err = c.upsert(rebase(obj, latest)); !isVersionMismatch(err) {
+			return upserted, err
+		}
+	}
+
+	return nil, &ConflictError{ID: id, Version: obj.Version, Err: err}
+}
+
+// Returns a copy of an update with the versions of the object and of the objects nested in it,
+// such as an item's variations, set to their versions in latest. New nested objects, and those
+// missing from latest, keep their versions.
+func rebase(obj, latest *CatalogObject) *CatalogObject {
+	model := *obj.CatalogObject
+	model.Version = latest.Version
+
+	// The nested objects are replaced, so the data holding them is copied first.
+	switch {
+	case model.ItemData != nil:
+		data := *model.ItemData
+		model.ItemData = &data
+	case model.ModifierListData != nil:
+		data := *model.ModifierListData
+		model.ModifierListData = &data
+	case model.ItemOptionData != nil:
+		data := *model.ItemOptionData
+		model.ItemOptionData = &data
+	}
+
+	rebased := &CatalogObject{CatalogObject: &model, Fields: obj.Fields}
+	models, _ := rebased.nested()
+	if models == nil {
+		return rebased
+	}
+
+	versions := map[string]int64{}
+	for _, child := range latest.Children() {
+		versions[StringValue(child.ID)] = child.Version
+	}
+
+	children := make([]*squaremodel.CatalogObject, len(*models))
+	for i, child := range *models {
+		copied := *child
+		if version, ok := versions[StringValue(child.ID)]; ok {
+			copied.Version = version
+		}
+		children[i] = &copied
+	}
+	*models = children
+
+	return rebased
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	squaremodel "github.com/jefflinse/square-connect/models"
+	"github.com/jefflinse/terraform-provider-square/square/client/squaretest"
+)
+
+// Creates a category and returns a copy of it renamed, ready to be written back as an update.
+func createTestCategoryUpdate(t *testing.T, c *Client) *CatalogObject {
+	created, err := c.UpsertCatalogObject(NewCatalogObject(&squaremodel.CatalogObject{
+		Type:         strPtr("CATEGORY"),
+		CategoryData: &squaremodel.CatalogCategory{Name: "Apparel"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewCatalogObject(&squaremodel.CatalogObject{
+		ID:           created.ID,
+		Type:         created.Type,
+		Version:      created.Version,
+		CategoryData: &squaremodel.CatalogCategory{Name: "Clothing"},
+	})
+}
+
+// Renames a category on the server before each of the next count upserts reach it, as if it were
+// renamed in the Square Dashboard.
+func renameBeforeUpserts(server *squaretest.Server, id string, count int) {
+	for i := 0; i < count; i++ {
+		server.BeforeNext("POST /v2/catalog/object", func() {
+			server.UpdateObject(id, func(obj squaretest.Object) {
+				obj["category_data"].(map[string]interface{})["name"] = "Outerwear"
+			})
+		})
+	}
+}
+
+func TestResolveConflict_fail(t *testing.T) {
+	server := squaretest.NewServer()
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	update := createTestCategoryUpdate(t, c)
+	renameBeforeUpserts(server, *update.ID, 1)
+
+	_, err := c.UpsertCatalogObject(update)
+
+	var conflictErr *ConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("error = %v, want a *ConflictError", err)
+	}
+	if conflictErr.ID != *update.ID || conflictErr.Version != update.Version {
+		t.Errorf("conflict names %s version %d, want %s version %d", conflictErr.ID, conflictErr.Version, *update.ID, update.Version)
+	}
+	if !isVersionMismatch(err) {
+		t.Errorf("conflict does not wrap Square's VERSION_MISMATCH error: %v", err)
+	}
+
+	if obj, _ := server.Object(*update.ID); obj["category_data"].(map[string]interface{})["name"] != "Outerwear" {
+		t.Errorf("category was overwritten with %v, want the concurrent change kept", obj["category_data"])
+	}
+}
+
+func TestResolveConflict_retry(t *testing.T) {
+	server := squaretest.NewServer()
+	defer server.Close()
+
+	c, err := NewClient(Config{
+		AccessToken:    "token",
+		BaseURL:        server.URL,
+		ConflictPolicy: ConflictPolicyRetry,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	update := createTestCategoryUpdate(t, c)
+
+	// Two more changes race the first two attempts to reapply the update.
+	renameBeforeUpserts(server, *update.ID, 3)
+
+	upserted, err := c.UpsertCatalogObject(update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upserted.CategoryData.Name != "Clothing" || upserted.Version <= update.Version {
+		t.Errorf("upserted %q at version %d, want %q at a version after %d", upserted.CategoryData.Name, upserted.Version, "Clothing", update.Version)
+	}
+
+	// The create, the update, two failed attempts to reapply it, and the successful one.
+	if n := countRequests(server, 0)["POST /v2/catalog/object"]; n != 5 {
+		t.Errorf("server received %d upserts, want 5", n)
+	}
+}
+
+func TestResolveConflict_retryNestedObjects(t *testing.T) {
+	server := squaretest.NewServer()
+	defer server.Close()
+
+	c, err := NewClient(Config{
+		AccessToken:    "token",
+		BaseURL:        server.URL,
+		ConflictPolicy: ConflictPolicyRetry,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := c.UpsertCatalogObject(newTestItem())
+	if err != nil {
+		t.Fatal(err)
+	}
+	variation := item.Children()[0]
+	variation.ItemVariationData.Name = "By the half pound"
+
+	// The variation is renamed in the Square Dashboard before the update reaches it.
+	server.BeforeNext("POST /v2/catalog/object", func() {
+		server.UpdateObject(*variation.ID, func(obj squaretest.Object) {
+			obj["item_variation_data"].(map[string]interface{})["name"] = "By the kilogram"
+		})
+	})
+
+	upserted, err := c.UpsertCatalogObject(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := upserted.ItemData.Variations[0].ItemVariationData.Name; name != "By the half pound" {
+		t.Errorf("variation is named %q, want %q", name, "By the half pound")
+	}
+	if item.ItemData.Variations[0].Version != variation.Version {
+		t.Errorf("update was modified by the retry")
+	}
+}
+
+func TestResolveConflict_retryGivesUp(t *testing.T) {
+	server := squaretest.NewServer()
+	defer server.Close()
+
+	c, err := NewClient(Config{
+		AccessToken:    "token",
+		BaseURL:        server.URL,
+		ConflictPolicy: ConflictPolicyRetry,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	update := createTestCategoryUpdate(t, c)
+	renameBeforeUpserts(server, *update.ID, 1+maxConflictRetries)
+
+	_, err = c.UpsertCatalogObject(update)
+
+	var conflictErr *ConflictError
+	if !errors.As(err, &conflictErr) || conflictErr.Version != update.Version {
+		t.Errorf("error = %v, want a *ConflictError for version %d", err, update.Version)
+	}
+}
@@ -0,0 +1,293 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	squaremodel "github.com/jefflinse/square-connect/models"
+)
+
+// zeroValueFields lists catalog object fields that must be sent even when they hold their zero
+// value. The generated models mark every field omitempty, so a false or 0 is dropped from the
+// request and Square applies its own default instead (e.g. a modifier list is enabled on an item
+// unless "enabled": false is sent). Each field is located by the path of data fields leading
+// from the catalog object to the JSON object holding it; lists along the path are walked.
+var zeroValueFields = []struct {
+	path  []string
+	field string
+	zero  interface{}
+}{
+	{[]string{"item_data", "modifier_list_info"}, "enabled", false},
+	{[]string{"item_data", "modifier_list_info"}, "max_selected_modifiers", 0},
+	{[]string{"item_data", "modifier_list_info"}, "min_selected_modifiers", 0},
+	{[]string{"item_variation_data"}, "inventory_alert_threshold", 0},
+	{[]string{"item_variation_data"}, "track_inventory", false},
+	{[]string{"measurement_unit_data"}, "precision", 0},
+}
+
+// nestedObjectFields locates the catalog objects nested in another catalog object.
+var nestedObjectFields = [][]string{
+	{"item_data", "variations"},
+	{"item_option_data", "values"},
+	{"modifier_list_data", "modifiers"},
+}
+
+// CatalogObject is a Square catalog object. The generated model it embeds predates some of the
+// fields Square supports, so the object also carries its JSON representation in Fields.
+type CatalogObject struct {
+	*squaremodel.CatalogObject
+
+	// Fields holds fields of the object's JSON representation, nested as they are in it. An object
+	// read from Square holds everything Square returned. When an object is written, the fields it
+	// holds are sent wherever the model leaves them out, so fields the model lacks are set here.
+	Fields map[string]interface{}
+}
+
+// NewCatalogObject returns a catalog object with the fields of the specified model.
+func NewCatalogObject(obj *squaremodel.CatalogObject) *CatalogObject {
+	return &CatalogObject{CatalogObject: obj, Fields: map[string]interface{}{}}
+}
+
+// Field returns the value found by following a path of JSON field names and list indices separated
+// by dots through Fields, e.g. "item_variation_data.location_overrides.0.sold_out".
+func (o *CatalogObject) Field(path string) (interface{}, bool) {
+	var v interface{} = o.Fields
+	for _, name := range strings.Split(path, ".") {
+		switch val := v.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if v, ok = val[name]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(name)
+			if err != nil || i < 0 || i >= len(val) {
+				return nil, false
+			}
+			v = val[i]
+		default:
+			return nil, false
+		}
+	}
+
+	return v, true
+}
+
+// SetField sets the value found by following a path like the one Field takes through Fields,
+// creating the objects and lists missing along the way.
+func (o *CatalogObject) SetField(path string, value interface{}) {
+	if o.Fields == nil {
+		o.Fields = map[string]interface{}{}
+	}
+
+	names := strings.Split(path, ".")
+	o.Fields = setField(o.Fields, names, value).(map[string]interface{})
+}
+
+// Sets value at the path of names within v, returning v or, if v had to be created or grown,
+// its replacement.
+func setField(v interface{}, names []string, value interface{}) interface{} {
+	if len(names) == 0 {
+		return value
+	}
+
+	if i, err := strconv.Atoi(names[0]); err == nil && i >= 0 {
+		list, _ := v.([]interface{})
+		for len(list) <= i {
+			list = append(list, nil)
+		}
+		list[i] = setField(list[i], names[1:], value)
+		return list
+	}
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		m = map[string]interface{}{}
+	}
+	m[names[0]] = setField(m[names[0]], names[1:], value)
+	return m
+}
+
+// Children returns the catalog objects nested in the object, such as an item's variations, with
+// the Fields the object holds for them.
+func (o *CatalogObject) Children() []*CatalogObject {
+	models, path := o.nested()
+	if models == nil {
+		return nil
+	}
+
+	children := make([]*CatalogObject, len(*models))
+	for i, model := range *models {
+		fields, _ := o.Field(path + "." + strconv.Itoa(i))
+		m, _ := fields.(map[string]interface{})
+		if m == nil {
+			m = map[string]interface{}{}
+		}
+		children[i] = &CatalogObject{CatalogObject: model, Fields: m}
+	}
+
+	return children
+}
+
+// SetChildren replaces the catalog objects nested in the object, along with the Fields they hold.
+// The object's data, such as the ItemData of an item, must already be set.
+func (o *CatalogObject) SetChildren(children []*CatalogObject) {
+	models, path := o.nested()
+	if models == nil {
+		return
+	}
+
+	if children == nil {
+		*models = nil
+		return
+	}
+
+	*models = make([]*squaremodel.CatalogObject, len(children))
+	fields := make([]interface{}, len(children))
+	for i, child := range children {
+		(*models)[i] = child.CatalogObject
+		fields[i] = child.Fields
+		if child.Fields == nil {
+			fields[i] = map[string]interface{}{}
+		}
+	}
+	o.SetField(path, fields)
+}
+
+// Returns the model's list of nested catalog objects and the path of the JSON field holding them.
+func (o *CatalogObject) nested() (*[]*squaremodel.CatalogObject, string) {
+	switch {
+	case o.CatalogObject == nil:
+		return nil, ""
+	case o.ItemData != nil:
+		return &o.ItemData.Variations, "item_data.variations"
+	case o.ModifierListData != nil:
+		return &o.ModifierListData.Modifiers, "modifier_list_data.modifiers"
+	case o.ItemOptionData != nil:
+		return &o.ItemOptionData.Values, "item_option_data.values"
+	}
+
+	return nil, ""
+}
+
+// MarshalJSON writes the model, along with the Fields it leaves out and the zero values listed in
+// zeroValueFields.
+func (o *CatalogObject) MarshalJSON() ([]byte, error) {
+	if o.CatalogObject == nil {
+		return json.Marshal(o.Fields)
+	}
+
+	b, err := json.Marshal(o.CatalogObject)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+
+	obj = mergeFields(obj, copyJSON(o.Fields)).(map[string]interface{})
+	fillZeroValues(obj)
+
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSON reads the model and keeps every field in Fields.
+func (o *CatalogObject) UnmarshalJSON(b []byte) error {
+	var model squaremodel.CatalogObject
+	if err := json.Unmarshal(b, &model); err != nil {
+		return err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+
+	o.CatalogObject, o.Fields = &model, fields
+	return nil
+}
+
+// Adds the fields missing from a JSON value to it from another, merging objects field by field and
+// lists element by element.
+func mergeFields(v, fields interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		m, _ := fields.(map[string]interface{})
+		for k, field := range m {
+			if existing, ok := val[k]; ok {
+				val[k] = mergeFields(existing, field)
+			} else {
+				val[k] = field
+			}
+		}
+	case []interface{}:
+		list, _ := fields.([]interface{})
+		for i := 0; i < len(val) && i < len(list); i++ {
+			val[i] = mergeFields(val[i], list[i])
+		}
+	}
+
+	return v
+}
+
+// Returns a copy of a decoded JSON value that shares none of its objects or lists.
+func copyJSON(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, elem := range val {
+			m[k] = copyJSON(elem)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, len(val))
+		for i, elem := range val {
+			list[i] = copyJSON(elem)
+		}
+		return list
+	}
+
+	return v
+}
+
+// Adds the zero value of every field in zeroValueFields missing from a catalog object, and does
+// the same for the catalog objects nested in it.
+func fillZeroValues(obj map[string]interface{}) {
+	for _, f := range zeroValueFields {
+		walk(obj, f.path, func(m map[string]interface{}) {
+			if _, ok := m[f.field]; !ok {
+				m[f.field] = f.zero
+			}
+		})
+	}
+
+	for _, path := range nestedObjectFields {
+		walk(obj, path, fillZeroValues)
+	}
+}
+
+// Calls fn with every JSON object found by following path from v, walking into each element of
+// the lists encountered along the way.
+func walk(v interface{}, path []string, fn func(map[string]interface{})) {
+	switch val := v.(type) {
+	case []interface{}:
+		for _, elem := range val {
+			walk(elem, path, fn)
+		}
+	case map[string]interface{}:
+		if len(path) == 0 {
+			fn(val)
+			return
+		}
+
+		if next, ok := val[path[0]]; ok {
+			walk(next, path[1:], fn)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	squaremodel "github.com/jefflinse/square-connect/models"
+	"github.com/jefflinse/terraform-provider-square/square/client/squaretest"
+)
+
+func TestCatalogObject_fields(t *testing.T) {
+	server := squaretest.NewServer()
+	defer server.Close()
+
+	c, err := NewClient(Config{
+		AccessToken: "token",
+		BaseURL:     server.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	variation := NewCatalogObject(&squaremodel.CatalogObject{
+		ID:                strPtr("#variation"),
+		Type:              strPtr("ITEM_VARIATION"),
+		ItemVariationData: &squaremodel.CatalogItemVariation{Name: "By the pound", PricingType: "VARIABLE_PRICING"},
+	})
+	variation.SetField("item_variation_data.stockable", false)
+
+	obj := NewCatalogObject(&squaremodel.CatalogObject{
+		ID:       strPtr("#item"),
+		Type:     strPtr("ITEM"),
+		ItemData: &squaremodel.CatalogItem{Name: "Smoked turkey"},
+	})
+	obj.SetChildren([]*CatalogObject{variation})
+
+	item, err := c.UpsertCatalogObject(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored, ok := server.Object(*item.ItemData.Variations[0].ID)
+	if !ok {
+		t.Fatal("variation was not created")
+	}
+	if got := stored["item_variation_data"].(map[string]interface{})["stockable"]; got != false {
+		t.Errorf("Square received stockable %v, want false", got)
+	}
+
+	if got, ok := item.Children()[0].Field("item_variation_data.stockable"); !ok || got != false {
+		t.Errorf("upserted item's variation has stockable %v, want false", got)
+	}
+
+	retrieved, err := c.RetrieveCatalogObject(*item.ItemData.Variations[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, ok := retrieved.Field("item_variation_data.stockable"); !ok || got != false {
+		t.Errorf("retrieved stockable %v, want false", got)
+	}
+	if got, ok := retrieved.Field("item_variation_data.sellable"); ok {
+		t.Errorf("retrieved sellable %v, want it unset", got)
+	}
+}
+
+func TestCatalogObject_nestedFields(t *testing.T) {
+	server := squaretest.NewServer()
+	defer server.Close()
+	server.AddLocation("LOCATION1", "Main Street")
+
+	c, err := NewClient(Config{
+		AccessToken: "token",
+		BaseURL:     server.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := c.UpsertCatalogObject(NewCatalogObject(&squaremodel.CatalogObject{
+		ID:       strPtr("#item"),
+		Type:     strPtr("ITEM"),
+		ItemData: &squaremodel.CatalogItem{Name: "Smoked turkey"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obj := NewCatalogObject(&squaremodel.CatalogObject{
+		ID:   strPtr("#variation"),
+		Type: strPtr("ITEM_VARIATION"),
+		ItemVariationData: &squaremodel.CatalogItemVariation{
+			ItemID:      *item.ID,
+			Name:        "By the pound",
+			PricingType: "VARIABLE_PRICING",
+			LocationOverrides: []*squaremodel.ItemVariationLocationOverrides{
+				{LocationID: "LOCATION1", PricingType: "VARIABLE_PRICING"},
+			},
+		},
+	})
+	obj.SetField("item_variation_data.location_overrides.0.sold_out", true)
+
+	variation, err := c.UpsertCatalogObject(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	retrieved, err := c.RetrieveCatalogObject(*variation.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	overrides := retrieved.ItemVariationData.LocationOverrides
+	if len(overrides) != 1 || overrides[0].LocationID != "LOCATION1" {
+		t.Fatalf("retrieved location overrides %v, want one for LOCATION1", overrides)
+	}
+	if got, _ := retrieved.Field("item_variation_data.location_overrides.0.sold_out"); got != true {
+		t.Errorf("retrieved sold_out %v, want true", got)
+	}
+}
+
+func TestCatalogObject_modelWins(t *testing.T) {
+	obj := NewCatalogObject(&squaremodel.CatalogObject{
+		ID:       strPtr("#item"),
+		Type:     strPtr("ITEM"),
+		ItemData: &squaremodel.CatalogItem{Name: "Smoked turkey"},
+	})
+	obj.SetField("item_data.name", "Roast beef")
+	obj.SetField("item_data.sort_name", "turkey")
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		ItemData struct {
+			Name     string `json:"name"`
+			SortName string `json:"sort_name"`
+		} `json:"item_data"`
+	}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.ItemData.Name != "Smoked turkey" {
+		t.Errorf("name = %q, want the model's name", got.ItemData.Name)
+	}
+	if got.ItemData.SortName != "turkey" {
+		t.Errorf("sort_name = %q, want the field set on the object", got.ItemData.SortName)
+	}
+
+	// Writing an object must not change the Fields it was written from.
+	if _, ok := obj.Field("present_at_all_locations"); ok {
+		t.Error("writing the object added zero values to its Fields")
+	}
+}
+
+func TestCatalogObject_locationsLeftToSquare(t *testing.T) {
+	server := squaretest.NewServer()
+	defer server.Close()
+
+	c, err := NewClient(Config{
+		AccessToken: "token",
+		BaseURL:     server.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An object that says nothing about its locations gets Square's default rather than a
+	// present_at_all_locations the caller never chose.
+	category, err := c.UpsertCatalogObject(NewCatalogObject(&squaremodel.CatalogObject{
+		ID:           strPtr("#category"),
+		Type:         strPtr("CATEGORY"),
+		CategoryData: &squaremodel.CatalogCategory{Name: "Apparel"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !category.PresentAtAllLocations {
+		t.Error("category is not present at all locations")
+	}
+
+	obj := NewCatalogObject(&squaremodel.CatalogObject{
+		ID:           strPtr("#hidden"),
+		Type:         strPtr("CATEGORY"),
+		CategoryData: &squaremodel.CatalogCategory{Name: "Hidden"},
+	})
+	obj.SetField("present_at_all_locations", false)
+
+	hidden, err := c.UpsertCatalogObject(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hidden.PresentAtAllLocations {
+		t.Error("category set to no locations is present at all locations")
+	}
+}
@@ -0,0 +1,148 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	runtime "github.com/go-openapi/runtime"
+	squaremodel "github.com/jefflinse/square-connect/models"
+)
+
+// ErrNotFound indicates that the requested object does not exist or has been deleted.
+var ErrNotFound = errors.New("not found")
+
+// Error is a single error reported by the Square API.
+type Error struct {
+	// Category is the high-level category of the error, e.g. INVALID_REQUEST_ERROR.
+	Category string
+
+	// Code identifies the specific error, e.g. VALUE_TOO_LONG.
+	Code string
+
+	// Detail is a human-readable description of the error.
+	Detail string
+
+	// Field is the request field the error refers to, if any.
+	Field string
+}
+
+func (e *Error) Error() string {
+	msg := e.Code
+	if e.Detail != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Detail)
+	}
+
+	if e.Field != "" {
+		msg = fmt.Sprintf("%s: %s", e.Field, msg)
+	}
+
+	return msg
+}
+
+// APIError is returned when Square rejects a request.
+type APIError struct {
+	// Operation is the Square API operation that failed, e.g. UpsertCatalogObject.
+	Operation string
+
+	// StatusCode is the HTTP status code of Square's response.
+	StatusCode int
+
+	// Errors are the individual errors Square reported, in the order it reported them.
+	Errors []*Error
+}
+
+func (e *APIError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("square: %s failed with status %d", e.Operation, e.StatusCode)
+	}
+
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+
+	return fmt.Sprintf("square: %s failed with status %d: %s", e.Operation, e.StatusCode, strings.Join(msgs, "; "))
+}
+
+// Is reports whether the error matches target, making Square's 404 responses match ErrNotFound.
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && (e.StatusCode == http.StatusNotFound || e.HasCode("NOT_FOUND"))
+}
+
+// HasCode reports whether Square reported an error with the specified code.
+func (e *APIError) HasCode(code string) bool {
+	for _, err := range e.Errors {
+		if err.Code == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Converts an error returned by the generated Square client into an *APIError carrying the
+// errors Square reported in its response body. Other errors are returned unchanged.
+func translateError(operation string, err error) error {
+	apiErr, ok := err.(*runtime.APIError)
+	if !ok {
+		return err
+	}
+
+	translated := &APIError{
+		Operation:  operation,
+		StatusCode: apiErr.Code,
+	}
+
+	resp, ok := apiErr.Response.(runtime.ClientResponse)
+	if !ok || resp.Body() == nil {
+		return translated
+	}
+
+	var body struct {
+		Errors []*squaremodel.Error `json:"errors"`
+	}
+	b, readErr := ioutil.ReadAll(resp.Body())
+	if readErr != nil || json.Unmarshal(b, &body) != nil {
+		return translated
+	}
+
+	for _, e := range body.Errors {
+		translated.Errors = append(translated.Errors, &Error{
+			Category: StringValue(e.Category),
+			Code:     StringValue(e.Code),
+			Detail:   e.Detail,
+			Field:    e.Field,
+		})
+	}
+
+	return translated
+}
+
+// errorBodyTransport is an http.RoundTripper that buffers the body of unsuccessful responses.
+// The generated client closes response bodies before handing errors back to the caller, so
+// without buffering Square's errors array would be lost by the time translateError runs.
+type errorBodyTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *errorBodyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode < http.StatusBadRequest {
+		return resp, err
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+	return resp, nil
+}
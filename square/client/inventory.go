@@ -0,0 +1,54 @@
+package client
+
+import (
+	inventoryAPI "github.com/jefflinse/square-connect/client/inventory"
+	squaremodel "github.com/jefflinse/square-connect/models"
+)
+
+// BatchChangeInventory applies inventory changes, such as physical counts, in a single request
+// and returns the resulting counts. Either every change is applied or none is.
+func (c *Client) BatchChangeInventory(changes []*squaremodel.InventoryChange) ([]*squaremodel.InventoryCount, error) {
+	key, err := idempotencyKeyFor(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	params := inventoryAPI.NewBatchChangeInventoryParams().WithBody(&squaremodel.BatchChangeInventoryRequest{
+		Changes:        changes,
+		IdempotencyKey: *key,
+	})
+
+	resp, err := c.square.Inventory.BatchChangeInventory(params, c.auth())
+	if err != nil {
+		return nil, translateError("BatchChangeInventory", err)
+	}
+
+	return resp.Payload.Counts, nil
+}
+
+// RetrieveInventoryCount retrieves the count of a catalog object in the specified state at a
+// location. It returns ErrNotFound if the object has never been counted there.
+func (c *Client) RetrieveInventoryCount(catalogObjectID, locationID, state string) (*squaremodel.InventoryCount, error) {
+	params := inventoryAPI.NewRetrieveInventoryCountParams().
+		WithCatalogObjectID(catalogObjectID).
+		WithLocationIds(strPtr(locationID))
+
+	for {
+		resp, err := c.square.Inventory.RetrieveInventoryCount(params, c.auth())
+		if err != nil {
+			return nil, translateError("RetrieveInventoryCount", err)
+		}
+
+		for _, count := range resp.Payload.Counts {
+			if count.LocationID == locationID && count.State == state {
+				return count, nil
+			}
+		}
+
+		if resp.Payload.Cursor == "" {
+			return nil, ErrNotFound
+		}
+
+		params = params.WithCursor(strPtr(resp.Payload.Cursor))
+	}
+}
@@ -0,0 +1,29 @@
+package client
+
+import (
+	locationsAPI "github.com/jefflinse/square-connect/client/locations"
+	squaremodel "github.com/jefflinse/square-connect/models"
+)
+
+// ListLocations lists the business's locations. The list is read once per client and reused,
+// since validating a plan looks the same locations up for every catalog object in it.
+func (c *Client) ListLocations() ([]*squaremodel.Location, error) {
+	c.locationsMu.Lock()
+	defer c.locationsMu.Unlock()
+
+	if c.locations != nil {
+		return c.locations, nil
+	}
+
+	resp, err := c.square.Locations.ListLocations(locationsAPI.NewListLocationsParams(), c.auth())
+	if err != nil {
+		return nil, translateError("ListLocations", err)
+	}
+
+	c.locations = resp.Payload.Locations
+	if c.locations == nil {
+		c.locations = []*squaremodel.Location{}
+	}
+
+	return c.locations, nil
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// DefaultLogBodyLimit is the number of bytes of each request and response body written to the log.
+const DefaultLogBodyLimit = 4096
+
+// secretFieldPattern matches JSON fields in request and response bodies that hold credentials.
+var secretFieldPattern = regexp.MustCompile(`"(access_token|refresh_token|client_secret)"\s*:\s*"[^"]*"`)
+
+// loggingTransport is an http.RoundTripper that logs every request sent to Square and the
+// response it got through Terraform's log levels: a summary of each exchange at DEBUG and the
+// bodies at TRACE. Every line carries the Square-Request-Id of the response and the idempotency
+// key of the request, when there are any, so a failure can be correlated with Square support.
+// Credentials are never logged.
+type loggingTransport struct {
+	next      http.RoundTripper
+	token     string
+	bodyLimit int
+}
+
+func newLoggingTransport(next http.RoundTripper, token string, bodyLimit int) *loggingTransport {
+	return &loggingTransport{
+		next:      next,
+		token:     token,
+		bodyLimit: bodyLimit,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	ids := ""
+	if m := idempotencyKeyPattern.FindSubmatch(body); m != nil {
+		ids = fmt.Sprintf(" idempotency_key=%s", m[1])
+	}
+
+	log.Printf("[DEBUG] square:%s request: %s %s", ids, req.Method, req.URL.RequestURI())
+	if len(body) > 0 {
+		log.Printf("[TRACE] square:%s request body: %s", ids, t.describe(body, req.Header.Get("Content-Type")))
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		log.Printf("[DEBUG] square:%s %s %s failed after %s: %s", ids, req.Method, req.URL.Path, elapsed, err)
+		return nil, err
+	}
+
+	if id := resp.Header.Get("Square-Request-Id"); id != "" {
+		ids = fmt.Sprintf(" request_id=%s%s", id, ids)
+	}
+
+	log.Printf("[DEBUG] square:%s response: %s %s returned %d in %s", ids, req.Method, req.URL.Path, resp.StatusCode, elapsed)
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	if len(respBody) > 0 {
+		log.Printf("[TRACE] square:%s response body: %s", ids, t.describe(respBody, resp.Header.Get("Content-Type")))
+	}
+
+	return resp, nil
+}
+
+// Prepares a body for the log. A JSON body has its credentials masked and is truncated to the
+// limit; any other body, such as an image upload, is described by its size and content type.
+func (t *loggingTransport) describe(body []byte, contentType string) string {
+	if mediaType, _, _ := mime.ParseMediaType(contentType); contentType != "" && !strings.HasSuffix(mediaType, "json") {
+		return fmt.Sprintf("(%d bytes of %s)", len(body), mediaType)
+	}
+
+	s := secretFieldPattern.ReplaceAllString(string(body), `"$1":"REDACTED"`)
+	if t.token != "" {
+		s = strings.ReplaceAll(s, t.token, "REDACTED")
+	}
+
+	if t.bodyLimit > 0 && len(s) > t.bodyLimit {
+		// Cut before the character straddling the limit rather than through it.
+		n := t.bodyLimit
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		return fmt.Sprintf("%s... (%d bytes truncated)", s[:n], len(s)-n)
+	}
+
+	return s
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	squaremodel "github.com/jefflinse/square-connect/models"
+	"github.com/jefflinse/terraform-provider-square/square/client/squaretest"
+)
+
+func TestLoggingTransport(t *testing.T) {
+	server := squaretest.NewServer()
+	defer server.Close()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	c, err := NewClient(Config{
+		AccessToken:  "secret-access-token",
+		BaseURL:      server.URL,
+		LogBodyLimit: 64,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.UpsertCatalogObject(NewCatalogObject(&squaremodel.CatalogObject{
+		ID:           strPtr("#category"),
+		Type:         strPtr("CATEGORY"),
+		CategoryData: &squaremodel.CatalogCategory{Name: strings.Repeat("x", 100)},
+	})); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	if strings.Contains(out, "secret-access-token") {
+		t.Errorf("log contains the access token:\n%s", out)
+	}
+
+	for _, want := range []string{
+		"[DEBUG] square: idempotency_key=",
+		"request: POST /v2/catalog/object",
+		"request_id=fake-request-1 idempotency_key=",
+		"returned 200",
+		"[TRACE] square:",
+		"bytes truncated)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestLoggingTransport_describe(t *testing.T) {
+	lt := newLoggingTransport(nil, "secret-access-token", 5)
+
+	for _, tc := range []struct {
+		body, contentType, want string
+	}{
+		{`{"a":1}`, "application/json", `{"a":... (2 bytes truncated)`},
+		{`{"a":1}`, "", `{"a":... (2 bytes truncated)`},
+		// The limit falls between the two bytes of the second "é".
+		{`"aéé"`, "application/json; charset=utf-8", `"aé... (3 bytes truncated)`},
+		{`"secret-access-token"`, "application/json", `"REDA... (5 bytes truncated)`},
+		{"--boundary\r\nContent-Type: image/png\r\n\r\n\x89PNG", "multipart/form-data; boundary=boundary", "(43 bytes of multipart/form-data)"},
+	} {
+		got := lt.describe([]byte(tc.body), tc.contentType)
+		if got != tc.want {
+			t.Errorf("describe(%q, %q) = %q, want %q", tc.body, tc.contentType, got, tc.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("describe(%q, %q) = %q, which is not valid UTF-8", tc.body, tc.contentType, got)
+		}
+	}
+}
+
+func TestLoggingTransport_redactsSecrets(t *testing.T) {
+	lt := newLoggingTransport(nil, "secret-access-token", DefaultLogBodyLimit)
+
+	// An error's code stays readable, while the credentials around it are redacted.
+	body := `{"access_token":"EAAA123","errors":[{"category":"INVALID_REQUEST_ERROR","code":"VERSION_MISMATCH"}]}`
+	want := `{"access_token":"REDACTED","errors":[{"category":"INVALID_REQUEST_ERROR","code":"VERSION_MISMATCH"}]}`
+	if got := lt.describe([]byte(body), "application/json"); got != want {
+		t.Errorf("describe(%q) = %q, want %q", body, got, want)
+	}
+}
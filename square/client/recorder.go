@@ -0,0 +1,263 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// CassetteModeRecord sends requests to Square and records every interaction to the cassette.
+	CassetteModeRecord = "record"
+
+	// CassetteModeReplay answers requests from the cassette without touching the network.
+	CassetteModeReplay = "replay"
+)
+
+// idempotencyKeyPattern matches the idempotency key in a request body.
+var idempotencyKeyPattern = regexp.MustCompile(`"idempotency_key"\s*:\s*"([^"]*)"`)
+
+// tempIDPattern matches the temporary IDs of new catalog objects in a request or response body.
+var tempIDPattern = regexp.MustCompile(`"#[^"]*"`)
+
+// Cassette is a recording of HTTP interactions with Square.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and the response Square gave to it. Credentials are
+// never recorded and idempotency keys are scrubbed from request bodies.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the part of a request that is recorded in a cassette.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response recorded in a cassette.
+type RecordedResponse struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+// recordedHeaders are the response headers worth keeping in a cassette.
+var recordedHeaders = []string{"Content-Type", "Retry-After", "Square-Request-Id", "Square-Version"}
+
+// recorderTransport is an http.RoundTripper that records interactions with Square to a cassette
+// file, or replays them from one. Replayed requests are matched to recorded interactions by
+// method, path, query, and JSON body, in the order they were recorded. Bodies are compared
+// without their idempotency keys and temporary IDs, which are random; the temporary IDs in a
+// replayed response are those of the request it answers. The other bodies, such as image uploads,
+// are not compared, as their multipart boundaries differ between runs.
+type recorderTransport struct {
+	next http.RoundTripper
+	mode string
+	path string
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+func newRecorderTransport(next http.RoundTripper, mode, path string) (*recorderTransport, error) {
+	t := &recorderTransport{
+		next:     next,
+		mode:     mode,
+		path:     path,
+		cassette: &Cassette{},
+	}
+
+	switch mode {
+	case CassetteModeRecord:
+	case CassetteModeReplay:
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+
+		if err := json.Unmarshal(b, t.cassette); err != nil {
+			return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+		}
+
+		t.used = make([]bool, len(t.cassette.Interactions))
+	default:
+		return nil, fmt.Errorf("unknown cassette mode '%s'", mode)
+	}
+
+	return t, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *recorderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.RawQuery,
+		Body:   idempotencyKeyPattern.ReplaceAllString(string(body), `"idempotency_key":"REDACTED"`),
+	}
+
+	if t.mode == CassetteModeReplay {
+		return t.replay(req, recorded)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	interaction := &Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    map[string]string{},
+			Body:       string(respBody),
+		},
+	}
+	for _, h := range recordedHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			interaction.Response.Headers[h] = v
+		}
+	}
+
+	if err := t.record(interaction); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (t *recorderTransport) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, interaction := range t.cassette.Interactions {
+		r := interaction.Request
+		if t.used[i] || r.Method != recorded.Method || r.Path != recorded.Path || r.Query != recorded.Query ||
+			!sameBody(r.Body, recorded.Body) {
+			continue
+		}
+
+		t.used[i] = true
+		body := replaceTempIDs(interaction.Response.Body, r.Body, recorded.Body)
+		resp := &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{},
+			Body:          ioutil.NopCloser(bytes.NewReader([]byte(body))),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}
+		for k, v := range interaction.Response.Headers {
+			resp.Header.Set(k, v)
+		}
+
+		return resp, nil
+	}
+
+	return nil, fmt.Errorf("cassette %s has no unused interaction for %s %s", t.path, recorded.Method, recorded.Path)
+}
+
+// Appends an interaction to the cassette and rewrites the cassette file.
+func (t *recorderTransport) record(interaction *Interaction) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cassette.Interactions = append(t.cassette.Interactions, interaction)
+	b, err := json.MarshalIndent(t.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(t.path, b, os.FileMode(0644))
+}
+
+// Reports whether a request body matches a recorded one. JSON bodies match if they hold the same
+// values apart from their idempotency keys and temporary IDs, regardless of formatting and field
+// order.
+func sameBody(recorded, sent string) bool {
+	a, ok := normalizeBody(recorded)
+	if !ok {
+		return true
+	}
+
+	b, ok := normalizeBody(sent)
+	return ok && a == b
+}
+
+// Returns a JSON body re-encoded without its idempotency key or temporary IDs, and whether it is
+// JSON at all.
+func normalizeBody(body string) (string, bool) {
+	if body == "" {
+		return "", true
+	}
+	body = tempIDPattern.ReplaceAllString(body, `"#"`)
+
+	var v interface{}
+	dec := json.NewDecoder(strings.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return "", false
+	}
+
+	if m, ok := v.(map[string]interface{}); ok {
+		delete(m, "idempotency_key")
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", false
+	}
+
+	return string(b), true
+}
+
+// Rewrites the temporary IDs of a recorded request in the response recorded for it to the
+// temporary IDs of the request being answered, in the order they appear in the requests.
+func replaceTempIDs(body, recorded, sent string) string {
+	from := tempIDPattern.FindAllString(recorded, -1)
+	to := tempIDPattern.FindAllString(sent, -1)
+	if len(from) == 0 || len(from) != len(to) {
+		return body
+	}
+
+	pairs := make([]string, 0, 2*len(from))
+	for i := range from {
+		pairs = append(pairs, from[i], to[i])
+	}
+
+	return strings.NewReplacer(pairs...).Replace(body)
+}
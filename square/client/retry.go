@@ -0,0 +1,149 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the default number of times a rate limited or failed request is retried.
+	DefaultMaxRetries = 4
+
+	// DefaultMaxRetryWait is the default upper bound on the delay between two attempts of a request.
+	DefaultMaxRetryWait = 30 * time.Second
+
+	minRetryWait = 500 * time.Millisecond
+)
+
+// retryTransport is an http.RoundTripper that retries requests Square rejected because of rate
+// limiting (429) or a transient server error (5xx), backing off exponentially with jitter between
+// attempts. Every attempt replays the exact same request body, so upserts keep their idempotency
+// key and a retried write can never be applied twice.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int, maxWait time.Duration) *retryTransport {
+	if maxWait <= 0 {
+		maxWait = DefaultMaxRetryWait
+	}
+
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		maxWait:    maxWait,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	}
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req.Clone(req.Context())
+		if body != nil {
+			attemptReq.Body = ioutil.NopCloser(bytes.NewReader(body))
+			attemptReq.GetBody = func() (io.ReadCloser, error) {
+				return ioutil.NopCloser(bytes.NewReader(body)), nil
+			}
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt >= t.maxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if resp != nil {
+			ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			log.Printf("[WARN] %s %s returned %d; retrying in %s (attempt %d of %d)", req.Method, req.URL.Path, resp.StatusCode, wait, attempt+1, t.maxRetries)
+		} else {
+			log.Printf("[WARN] %s %s failed: %s; retrying in %s (attempt %d of %d)", req.Method, req.URL.Path, err, wait, attempt+1, t.maxRetries)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Computes the delay before the next attempt, preferring the server's Retry-After header when present.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > t.maxWait {
+				return t.maxWait
+			}
+			return wait
+		}
+	}
+
+	wait := minRetryWait << uint(attempt)
+	if wait <= 0 || wait > t.maxWait {
+		wait = t.maxWait
+	}
+
+	// Equal jitter: wait somewhere between half and all of the computed delay.
+	half := int64(wait / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+// Reports whether a request should be attempted again given the outcome of the previous attempt.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// Parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		wait := time.Until(at)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,209 @@
+package client
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	squaremodel "github.com/jefflinse/square-connect/models"
+	"github.com/jefflinse/terraform-provider-square/square/client/squaretest"
+)
+
+// Starts a server that answers each request with the next of the specified statuses, and 200
+// once they run out, recording the body of every request it receives.
+func newStatusServer(t *testing.T, header http.Header, statuses ...int) (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	bodies := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+
+		mu.Lock()
+		bodies = append(bodies, string(b))
+		status := http.StatusOK
+		if len(bodies) <= len(statuses) {
+			status = statuses[len(bodies)-1]
+		}
+		mu.Unlock()
+
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string{}, bodies...)
+	}
+}
+
+func sendThroughRetryTransport(t *testing.T, rt http.RoundTripper, url string) *http.Response {
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(`{"idempotency_key":"key"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	return resp
+}
+
+func TestRetryTransport_retriesTransientFailures(t *testing.T) {
+	server, bodies := newStatusServer(t, http.Header{"Retry-After": {"0"}},
+		http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable)
+
+	resp := sendThroughRetryTransport(t, newRetryTransport(http.DefaultTransport, 4, time.Second), server.URL)
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	got := bodies()
+	if len(got) != 5 {
+		t.Fatalf("server received %d attempts, want 5", len(got))
+	}
+	for i, body := range got {
+		if body != `{"idempotency_key":"key"}` {
+			t.Errorf("attempt %d sent body %q, want the original body", i+1, body)
+		}
+	}
+}
+
+func TestRetryTransport_givesUp(t *testing.T) {
+	server, bodies := newStatusServer(t, http.Header{"Retry-After": {"0"}},
+		http.StatusGatewayTimeout, http.StatusGatewayTimeout, http.StatusGatewayTimeout, http.StatusGatewayTimeout)
+
+	resp := sendThroughRetryTransport(t, newRetryTransport(http.DefaultTransport, 2, time.Second), server.URL)
+	if resp.StatusCode != http.StatusGatewayTimeout {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusGatewayTimeout)
+	}
+	if n := len(bodies()); n != 3 {
+		t.Errorf("server received %d attempts, want 3", n)
+	}
+}
+
+func TestRetryTransport_nonRetryableStatuses(t *testing.T) {
+	for _, status := range []int{
+		http.StatusBadRequest,
+		http.StatusUnauthorized,
+		http.StatusForbidden,
+		http.StatusNotFound,
+		http.StatusConflict,
+		http.StatusNotImplemented,
+	} {
+		server, bodies := newStatusServer(t, http.Header{"Retry-After": {"0"}}, status)
+
+		resp := sendThroughRetryTransport(t, newRetryTransport(http.DefaultTransport, 4, time.Second), server.URL)
+		if resp.StatusCode != status {
+			t.Errorf("status = %d, want %d", resp.StatusCode, status)
+		}
+		if n := len(bodies()); n != 1 {
+			t.Errorf("%d: server received %d attempts, want 1", status, n)
+		}
+	}
+}
+
+func TestRetryTransport_honorsRetryAfter(t *testing.T) {
+	server, bodies := newStatusServer(t, http.Header{"Retry-After": {"1"}}, http.StatusTooManyRequests)
+
+	start := time.Now()
+	resp := sendThroughRetryTransport(t, newRetryTransport(http.DefaultTransport, 4, 10*time.Second), server.URL)
+	elapsed := time.Since(start)
+
+	if resp.StatusCode != http.StatusOK || len(bodies()) != 2 {
+		t.Fatalf("got status %d after %d attempts, want 200 after 2", resp.StatusCode, len(bodies()))
+	}
+	if elapsed < time.Second {
+		t.Errorf("retried after %s, want at least the 1s Square asked for", elapsed)
+	}
+}
+
+func TestRetryTransport_capsRetryAfter(t *testing.T) {
+	server, bodies := newStatusServer(t, http.Header{"Retry-After": {"120"}}, http.StatusTooManyRequests)
+
+	start := time.Now()
+	resp := sendThroughRetryTransport(t, newRetryTransport(http.DefaultTransport, 4, 50*time.Millisecond), server.URL)
+	elapsed := time.Since(start)
+
+	if resp.StatusCode != http.StatusOK || len(bodies()) != 2 {
+		t.Fatalf("got status %d after %d attempts, want 200 after 2", resp.StatusCode, len(bodies()))
+	}
+	if elapsed < 50*time.Millisecond || elapsed > 5*time.Second {
+		t.Errorf("retried after %s, want the 50ms max_retry_wait", elapsed)
+	}
+}
+
+func TestRetryTransport_backoff(t *testing.T) {
+	rt := newRetryTransport(http.DefaultTransport, 4, 3*time.Second)
+
+	for _, tc := range []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{0, 250 * time.Millisecond, 500 * time.Millisecond},
+		{1, 500 * time.Millisecond, time.Second},
+		{2, time.Second, 2 * time.Second},
+		// Capped at max_retry_wait.
+		{3, 1500 * time.Millisecond, 3 * time.Second},
+		{40, 1500 * time.Millisecond, 3 * time.Second},
+	} {
+		for i := 0; i < 100; i++ {
+			if wait := rt.backoff(tc.attempt, nil); wait < tc.min || wait > tc.max {
+				t.Fatalf("attempt %d waited %s, want between %s and %s", tc.attempt, wait, tc.min, tc.max)
+			}
+		}
+	}
+
+	at := &http.Response{Header: http.Header{"Retry-After": {time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)}}}
+	if wait := rt.backoff(0, at); wait != 3*time.Second {
+		t.Errorf("Retry-After an hour from now waited %s, want the 3s max_retry_wait", wait)
+	}
+
+	seconds := &http.Response{Header: http.Header{"Retry-After": {"2"}}}
+	if wait := rt.backoff(0, seconds); wait != 2*time.Second {
+		t.Errorf("Retry-After of 2 seconds waited %s, want 2s", wait)
+	}
+}
+
+func TestClient_retriesFailedRequests(t *testing.T) {
+	server := squaretest.NewServer()
+	defer server.Close()
+
+	c, err := NewClient(Config{
+		AccessToken:  "token",
+		BaseURL:      server.URL,
+		MaxRetries:   2,
+		MaxRetryWait: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server.FailNext(http.StatusServiceUnavailable, 2)
+	if _, err := c.UpsertCatalogObject(NewCatalogObject(&squaremodel.CatalogObject{
+		ID:           strPtr("#category"),
+		Type:         strPtr("CATEGORY"),
+		CategoryData: &squaremodel.CatalogCategory{Name: "Apparel"},
+	})); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(server.Requests()); n != 3 {
+		t.Errorf("server received %d requests, want 3", n)
+	}
+
+	server.FailNext(http.StatusServiceUnavailable, 3)
+	_, err = c.ListCatalog()
+	if apiErr, ok := err.(*APIError); !ok || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("error = %v, want a 503 once retries run out", err)
+	}
+}
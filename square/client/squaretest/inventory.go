@@ -0,0 +1,132 @@
+package squaretest
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// quantityPattern matches an inventory quantity, a non-negative decimal with at most 5 places.
+var quantityPattern = regexp.MustCompile(`^\d+(\.\d{1,5})?$`)
+
+// InventoryCount returns the IN_STOCK quantity of a catalog object at a location.
+func (s *Server) InventoryCount(catalogObjectID, locationID string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count, ok := s.inventory[catalogObjectID+"/"+locationID]
+	if !ok {
+		return "", false
+	}
+
+	return count["quantity"].(string), true
+}
+
+// SetInventoryCount changes the IN_STOCK quantity of a catalog object at a location as if it
+// changed outside of Terraform, e.g. because some of it sold. Only a count that exists can change.
+func (s *Server) SetInventoryCount(catalogObjectID, locationID, quantity string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := catalogObjectID + "/" + locationID
+	count, ok := s.inventory[key]
+	if !ok {
+		return false
+	}
+
+	count = deepCopy(count)
+	count["quantity"] = quantity
+	count["calculated_at"] = time.Now().UTC().Format(time.RFC3339)
+	s.inventory[key] = count
+	return true
+}
+
+// Applies a batch of inventory changes, all of which must be physical counts of item variations
+// at existing locations. Either every change is applied or, if any is invalid, none is.
+func (s *Server) batchChangeInventory(req map[string]interface{}) (interface{}, error) {
+	changes, _ := req["changes"].([]interface{})
+	if len(changes) == 0 {
+		return nil, invalidRequest("MISSING_REQUIRED_PARAMETER", "changes", "Field must be set")
+	}
+
+	counts := []Object{}
+	for i, c := range changes {
+		change, _ := c.(map[string]interface{})
+		field := fmt.Sprintf("changes[%d]", i)
+		if change["type"] != "PHYSICAL_COUNT" {
+			return nil, invalidRequest("INVALID_VALUE", field+".type", "The fake Square server only supports PHYSICAL_COUNT changes.")
+		}
+
+		physical, _ := change["physical_count"].(map[string]interface{})
+		field += ".physical_count"
+		if physical == nil {
+			return nil, invalidRequest("MISSING_REQUIRED_PARAMETER", field, "Field must be set")
+		}
+
+		objectID, _ := physical["catalog_object_id"].(string)
+		if obj, ok := s.objects[objectID]; !ok || obj["type"] != "ITEM_VARIATION" {
+			return nil, invalidRequest("INVALID_VALUE", field+".catalog_object_id", "Object refers to an ITEM_VARIATION `%s` that does not exist.", objectID)
+		}
+
+		if !s.hasLocation(physical["location_id"]) {
+			return nil, invalidRequest("INVALID_VALUE", field+".location_id", "Location `%v` does not exist.", physical["location_id"])
+		}
+
+		if physical["state"] != "IN_STOCK" {
+			return nil, invalidRequest("INVALID_VALUE", field+".state", "The fake Square server only supports IN_STOCK counts.")
+		}
+
+		quantity, _ := physical["quantity"].(string)
+		if !quantityPattern.MatchString(quantity) {
+			return nil, invalidRequest("INVALID_VALUE", field+".quantity", "Quantity `%s` is not a non-negative decimal with at most 5 decimal places.", quantity)
+		}
+
+		if _, err := time.Parse(time.RFC3339, fmt.Sprint(physical["occurred_at"])); err != nil {
+			return nil, invalidRequest("INVALID_VALUE", field+".occurred_at", "Field must be an RFC 3339 timestamp.")
+		}
+
+		counts = append(counts, Object{
+			"catalog_object_id":   objectID,
+			"catalog_object_type": "ITEM_VARIATION",
+			"location_id":         physical["location_id"],
+			"quantity":            quantity,
+			"state":               "IN_STOCK",
+			"calculated_at":       time.Now().UTC().Format(time.RFC3339),
+		})
+	}
+
+	for _, count := range counts {
+		s.inventory[fmt.Sprintf("%s/%s", count["catalog_object_id"], count["location_id"])] = count
+	}
+
+	return map[string]interface{}{"counts": counts}, nil
+}
+
+// Returns the counts of a catalog object at the specified comma-separated locations, or at every
+// location if none are specified.
+func (s *Server) retrieveInventoryCount(objectID, locationIDs string) (interface{}, error) {
+	keys := []string{}
+	for key := range s.inventory {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	wanted := map[string]bool{}
+	for _, id := range strings.Split(locationIDs, ",") {
+		if id != "" {
+			wanted[id] = true
+		}
+	}
+
+	counts := []Object{}
+	for _, key := range keys {
+		count := s.inventory[key]
+		if count["catalog_object_id"] == objectID && (len(wanted) == 0 || wanted[count["location_id"].(string)]) {
+			counts = append(counts, count)
+		}
+	}
+
+	return map[string]interface{}{"counts": counts}, nil
+}
@@ -0,0 +1,1181 @@
+// Package squaretest provides an in-process fake of Square's Catalog API for testing the client
+// and the provider without network access or credentials.
+//
+// The fake stores catalog objects as raw JSON, so any attribute a request sends is returned by
+// later reads. It implements object versions, temporary client ID mapping (including references
+// between objects in the same request), nested item variations, modifiers, and option values,
+// image uploads, idempotency keys, cursor pagination, and Square-shaped error responses. It also
+// lists the locations added with AddLocation, which catalog objects can be scoped to, and records
+// the physical inventory counts of item variations at those locations.
+package squaretest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// htmlTags matches the tags of an HTML description, which Square strips to derive a plain text one.
+var htmlTags = regexp.MustCompile(`<[^>]*>`)
+
+// DefaultPageSize is the number of objects the server returns per page of a listing or search.
+const DefaultPageSize = 100
+
+// Object is a catalog object as it appears in Square's JSON representation.
+type Object = map[string]interface{}
+
+// parentTypes maps each catalog type that nests children to the type of its children, the
+// field of its data holding them, and the field of each child referring back to the parent.
+var parentTypes = map[string]struct {
+	childType  string
+	childField string
+	parentRef  string
+}{
+	"ITEM":          {"ITEM_VARIATION", "variations", "item_id"},
+	"MODIFIER_LIST": {"MODIFIER", "modifiers", "modifier_list_id"},
+	"ITEM_OPTION":   {"ITEM_OPTION_VAL", "values", "item_option_id"},
+}
+
+// Server is a fake Square API server. Create one with NewServer and point a client at its URL.
+type Server struct {
+	*httptest.Server
+
+	// PageSize is the number of objects returned per page of a listing or search.
+	PageSize int
+
+	mu          sync.Mutex
+	objects     map[string]Object
+	order       []string
+	version     int64
+	nextID      int
+	idempotency map[string]idempotentResponse
+	failures    []int
+	requests    []string
+	handlers    map[string]http.HandlerFunc
+	hooks       map[string][]func()
+	images      map[string][]byte
+	locations   []Object
+	inventory   map[string]Object
+}
+
+type idempotentResponse struct {
+	requestHash [32]byte
+	body        interface{}
+}
+
+// squareError is a single error in a Square error response.
+type squareError struct {
+	Category string `json:"category"`
+	Code     string `json:"code"`
+	Detail   string `json:"detail,omitempty"`
+	Field    string `json:"field,omitempty"`
+}
+
+// requestError is returned by request handlers to send a Square error response.
+type requestError struct {
+	status int
+	errors []squareError
+}
+
+func (e *requestError) Error() string {
+	return fmt.Sprintf("%d: %v", e.status, e.errors)
+}
+
+func invalidRequest(code, field, detail string, args ...interface{}) *requestError {
+	return &requestError{
+		status: http.StatusBadRequest,
+		errors: []squareError{{
+			Category: "INVALID_REQUEST_ERROR",
+			Code:     code,
+			Detail:   fmt.Sprintf(detail, args...),
+			Field:    field,
+		}},
+	}
+}
+
+func notFound(id string) *requestError {
+	return &requestError{
+		status: http.StatusNotFound,
+		errors: []squareError{{
+			Category: "INVALID_REQUEST_ERROR",
+			Code:     "NOT_FOUND",
+			Detail:   fmt.Sprintf("Object `%s` not found.", id),
+		}},
+	}
+}
+
+// NewServer starts and returns a new fake Square server. The caller should call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		PageSize:    DefaultPageSize,
+		objects:     map[string]Object{},
+		idempotency: map[string]idempotentResponse{},
+		handlers:    map[string]http.HandlerFunc{},
+		hooks:       map[string][]func(){},
+		images:      map[string][]byte{},
+		locations:   []Object{},
+		inventory:   map[string]Object{},
+		version:     time.Now().UnixNano() / int64(time.Millisecond),
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// AddLocation adds an active location to the business the server fakes.
+func (s *Server) AddLocation(id, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.locations = append(s.locations, Object{"id": id, "name": name, "status": "ACTIVE"})
+}
+
+// Handle registers an additional handler for requests matching pattern, which is a method and
+// path such as "POST /v2/locations". It is used to extend the fake with other Square APIs.
+func (s *Server) Handle(pattern string, handler http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[pattern] = handler
+}
+
+// FailNext makes the next count requests fail with the specified HTTP status code.
+func (s *Server) FailNext(status int, count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < count; i++ {
+		s.failures = append(s.failures, status)
+	}
+}
+
+// BeforeNext makes the server call fn before handling the next request matching pattern, which is
+// a method and path such as "POST /v2/catalog/object". It is used to change the catalog while a
+// request is in flight, e.g. to modify an object between the time it is read and written.
+func (s *Server) BeforeNext(pattern string, fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks[pattern] = append(s.hooks[pattern], fn)
+}
+
+// Requests returns the method and path of every request the server has received, in order.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.requests...)
+}
+
+// Object returns a copy of the stored catalog object with the specified ID as Square would
+// return it, including nested children.
+func (s *Server) Object(id string) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.objects[id]; !ok {
+		return nil, false
+	}
+
+	return s.render(id), true
+}
+
+// DeleteObject deletes a catalog object and its children as if it were deleted outside of
+// Terraform, e.g. in the Square Dashboard.
+func (s *Server) DeleteObject(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.delete(id)) > 0
+}
+
+// UpdateObject modifies a catalog object as if it were edited outside of Terraform, e.g. in the
+// Square Dashboard, giving it a new version.
+func (s *Server) UpdateObject(id string, update func(Object)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.objects[id]
+	if !ok {
+		return false
+	}
+
+	obj = deepCopy(obj)
+	update(obj)
+	s.store(obj)
+	return true
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, &requestError{status: http.StatusBadRequest, errors: []squareError{{Category: "INVALID_REQUEST_ERROR", Code: "BAD_REQUEST", Detail: err.Error()}}})
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	w.Header().Set("Square-Request-Id", fmt.Sprintf("fake-request-%d", len(s.requests)))
+	var failure int
+	if len(s.failures) > 0 {
+		failure, s.failures = s.failures[0], s.failures[1:]
+	}
+	handler := s.handlers[r.Method+" "+r.URL.Path]
+	var hook func()
+	if hooks := s.hooks[r.Method+" "+r.URL.Path]; len(hooks) > 0 {
+		hook, s.hooks[r.Method+" "+r.URL.Path] = hooks[0], hooks[1:]
+	}
+	s.mu.Unlock()
+
+	if hook != nil {
+		hook()
+	}
+
+	if failure != 0 {
+		writeError(w, &requestError{status: failure, errors: []squareError{{Category: "API_ERROR", Code: "INTERNAL_SERVER_ERROR", Detail: "injected failure"}}})
+		return
+	}
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") || r.Header.Get("Authorization") == "Bearer " {
+		writeError(w, &requestError{status: http.StatusUnauthorized, errors: []squareError{{Category: "AUTHENTICATION_ERROR", Code: "UNAUTHORIZED", Detail: "This request could not be authorized."}}})
+		return
+	}
+
+	if handler != nil {
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		handler(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var resp interface{}
+	switch path := r.URL.Path; {
+	case r.Method == http.MethodPost && path == "/v2/catalog/object":
+		resp, err = s.idempotent(body, s.upsertObject)
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/v2/catalog/object/"):
+		resp, err = s.retrieveObject(strings.TrimPrefix(path, "/v2/catalog/object/"))
+	case r.Method == http.MethodDelete && strings.HasPrefix(path, "/v2/catalog/object/"):
+		resp, err = s.deleteObject(strings.TrimPrefix(path, "/v2/catalog/object/"))
+	case r.Method == http.MethodPost && path == "/v2/catalog/batch-upsert":
+		resp, err = s.idempotent(body, s.batchUpsert)
+	case r.Method == http.MethodPost && path == "/v2/catalog/batch-retrieve":
+		resp, err = s.batchRetrieve(body)
+	case r.Method == http.MethodPost && path == "/v2/catalog/batch-delete":
+		resp, err = s.batchDelete(body)
+	case r.Method == http.MethodGet && path == "/v2/catalog/list":
+		resp, err = s.list(r.URL.Query().Get("types"), r.URL.Query().Get("cursor"))
+	case r.Method == http.MethodPost && path == "/v2/catalog/search":
+		resp, err = s.search(body)
+	case r.Method == http.MethodPost && path == "/v2/catalog/images":
+		resp, err = s.createImage(r.Header.Get("Content-Type"), body)
+	case r.Method == http.MethodGet && path == "/v2/locations":
+		resp = map[string]interface{}{"locations": s.locations}
+	case r.Method == http.MethodPost && path == "/v2/inventory/batch-change":
+		resp, err = s.idempotent(body, s.batchChangeInventory)
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/v2/inventory/"):
+		resp, err = s.retrieveInventoryCount(strings.TrimPrefix(path, "/v2/inventory/"), r.URL.Query().Get("location_ids"))
+	default:
+		err = &requestError{status: http.StatusNotFound, errors: []squareError{{Category: "INVALID_REQUEST_ERROR", Code: "NOT_FOUND", Detail: fmt.Sprintf("%s %s is not implemented by the fake Square server", r.Method, path)}}}
+	}
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, resp)
+}
+
+// WriteJSON writes a JSON response with the specified status code.
+func WriteJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// WriteError writes a Square error response with a single error.
+func WriteError(w http.ResponseWriter, status int, category, code, field, detail string) {
+	writeError(w, &requestError{status: status, errors: []squareError{{Category: category, Code: code, Detail: detail, Field: field}}})
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	reqErr, ok := err.(*requestError)
+	if !ok {
+		reqErr = &requestError{status: http.StatusInternalServerError, errors: []squareError{{Category: "API_ERROR", Code: "INTERNAL_SERVER_ERROR", Detail: err.Error()}}}
+	}
+
+	WriteJSON(w, reqErr.status, map[string]interface{}{"errors": reqErr.errors})
+}
+
+// Replays the stored response for a request whose idempotency key has been seen before, and
+// records the response of a new one.
+func (s *Server) idempotent(body []byte, handle func(map[string]interface{}) (interface{}, error)) (interface{}, error) {
+	var req map[string]interface{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, invalidRequest("INVALID_BODY", "", "Invalid JSON body: %s", err)
+	}
+
+	key, _ := req["idempotency_key"].(string)
+	if key == "" {
+		return nil, invalidRequest("MISSING_REQUIRED_PARAMETER", "idempotency_key", "Field must be set")
+	}
+
+	hash := sha256.Sum256(body)
+	if prev, ok := s.idempotency[key]; ok {
+		if prev.requestHash != hash {
+			return nil, invalidRequest("IDEMPOTENCY_KEY_REUSED", "idempotency_key", "The idempotency key `%s` was used for a different request.", key)
+		}
+		return prev.body, nil
+	}
+
+	resp, err := handle(req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.idempotency[key] = idempotentResponse{requestHash: hash, body: resp}
+	return resp, nil
+}
+
+// Image returns the file uploaded for the IMAGE catalog object with the specified ID.
+func (s *Server) Image(id string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, ok := s.images[id]
+	return file, ok
+}
+
+// Handles a CreateCatalogImage request, a multipart form holding the JSON request and the file.
+func (s *Server) createImage(contentType string, body []byte) (interface{}, error) {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil || params["boundary"] == "" {
+		return nil, invalidRequest("INVALID_CONTENT_TYPE", "", "Expected a multipart/form-data request.")
+	}
+
+	var request []byte
+	var file []byte
+	var fileType string
+	var filename string
+	mr := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err != nil {
+			break
+		}
+
+		b, err := ioutil.ReadAll(part)
+		if err != nil {
+			return nil, invalidRequest("INVALID_BODY", "", "Invalid multipart body: %s", err)
+		}
+
+		switch part.FormName() {
+		case "request":
+			request = b
+		case "image_file":
+			file, fileType, filename = b, part.Header.Get("Content-Type"), part.FileName()
+		}
+	}
+
+	if request == nil {
+		return nil, invalidRequest("MISSING_REQUIRED_PARAMETER", "request", "Field must be set")
+	}
+
+	switch fileType {
+	case "image/jpeg", "image/pjpeg", "image/png", "image/gif":
+	default:
+		return nil, invalidRequest("INVALID_CONTENT_TYPE", "image_file", "Only JPEG, PJPEG, PNG, and GIF images are supported, not `%s`.", fileType)
+	}
+
+	return s.idempotent(request, func(req map[string]interface{}) (interface{}, error) {
+		image, ok := req["image"].(map[string]interface{})
+		if !ok || image["type"] != "IMAGE" {
+			return nil, invalidRequest("INVALID_VALUE", "image", "Field must be a catalog object of type IMAGE.")
+		}
+
+		objectID, _ := req["object_id"].(string)
+		target, ok := s.objects[objectID]
+		if objectID != "" && !ok {
+			return nil, notFound(objectID)
+		}
+
+		ids, err := s.upsertAtomically([]interface{}{image}, func(int) string { return "image" })
+		if err != nil {
+			return nil, err
+		}
+
+		id := resolveID(image, ids)
+		data := s.objects[id]["image_data"].(map[string]interface{})
+		data["url"] = fmt.Sprintf("%s/images/%s/%s", s.URL, id, filename)
+		s.images[id] = file
+
+		if target != nil {
+			target["image_id"] = id
+			if itemData, ok := target["item_data"].(map[string]interface{}); ok {
+				imageIDs, _ := itemData["image_ids"].([]interface{})
+				itemData["image_ids"] = append([]interface{}{id}, imageIDs...)
+			}
+			s.store(target)
+		}
+
+		return map[string]interface{}{"image": s.render(id)}, nil
+	})
+}
+
+func (s *Server) upsertObject(req map[string]interface{}) (interface{}, error) {
+	obj, ok := req["object"].(map[string]interface{})
+	if !ok {
+		return nil, invalidRequest("MISSING_REQUIRED_PARAMETER", "object", "Field must be set")
+	}
+
+	ids, err := s.upsertAtomically([]interface{}{obj}, func(int) string { return "object" })
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"catalog_object": s.render(resolveID(obj, ids)),
+		"id_mappings":    idMappings(ids),
+	}, nil
+}
+
+func (s *Server) batchUpsert(req map[string]interface{}) (interface{}, error) {
+	batches, _ := req["batches"].([]interface{})
+	if len(batches) == 0 {
+		return nil, invalidRequest("MISSING_REQUIRED_PARAMETER", "batches", "Field must be set")
+	}
+
+	objects := []interface{}{}
+	rendered := []interface{}{}
+	allIDs := map[string]string{}
+	for i, b := range batches {
+		batch, _ := b.(map[string]interface{})
+		objs, _ := batch["objects"].([]interface{})
+		ids, err := s.upsertAtomically(objs, func(j int) string { return fmt.Sprintf("batches[%d].objects[%d]", i, j) })
+		if err != nil {
+			return nil, err
+		}
+
+		for tempID, id := range ids {
+			allIDs[tempID] = id
+		}
+		objects = append(objects, objs...)
+	}
+
+	for _, o := range objects {
+		rendered = append(rendered, s.render(resolveID(o.(map[string]interface{}), allIDs)))
+	}
+
+	return map[string]interface{}{
+		"objects":     rendered,
+		"id_mappings": idMappings(allIDs),
+		"updated_at":  time.Now().UTC().Format(time.RFC3339),
+	}, nil
+}
+
+func (s *Server) retrieveObject(id string) (interface{}, error) {
+	if _, ok := s.objects[id]; !ok {
+		return nil, notFound(id)
+	}
+
+	return map[string]interface{}{"object": s.render(id)}, nil
+}
+
+func (s *Server) deleteObject(id string) (interface{}, error) {
+	deleted := s.delete(id)
+	if len(deleted) == 0 {
+		return nil, notFound(id)
+	}
+
+	return map[string]interface{}{
+		"deleted_object_ids": deleted,
+		"deleted_at":         time.Now().UTC().Format(time.RFC3339),
+	}, nil
+}
+
+func (s *Server) batchRetrieve(body []byte) (interface{}, error) {
+	var req struct {
+		ObjectIDs []string `json:"object_ids"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, invalidRequest("INVALID_BODY", "", "Invalid JSON body: %s", err)
+	}
+
+	objects := []interface{}{}
+	for _, id := range req.ObjectIDs {
+		if _, ok := s.objects[id]; ok {
+			objects = append(objects, s.render(id))
+		}
+	}
+
+	return map[string]interface{}{"objects": objects}, nil
+}
+
+func (s *Server) batchDelete(body []byte) (interface{}, error) {
+	var req struct {
+		ObjectIDs []string `json:"object_ids"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, invalidRequest("INVALID_BODY", "", "Invalid JSON body: %s", err)
+	}
+
+	deleted := []string{}
+	for _, id := range req.ObjectIDs {
+		deleted = append(deleted, s.delete(id)...)
+	}
+
+	return map[string]interface{}{
+		"deleted_object_ids": deleted,
+		"deleted_at":         time.Now().UTC().Format(time.RFC3339),
+	}, nil
+}
+
+func (s *Server) list(types, cursor string) (interface{}, error) {
+	var wanted []string
+	if types != "" {
+		wanted = strings.Split(strings.ToUpper(types), ",")
+	}
+
+	objects, next, err := s.page(wanted, cursor, s.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"objects": objects, "cursor": next}, nil
+}
+
+func (s *Server) search(body []byte) (interface{}, error) {
+	var req struct {
+		ObjectTypes []string    `json:"object_types"`
+		Cursor      string      `json:"cursor"`
+		Limit       int         `json:"limit"`
+		Query       interface{} `json:"query"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, invalidRequest("INVALID_BODY", "", "Invalid JSON body: %s", err)
+	}
+
+	if req.Query != nil {
+		return nil, invalidRequest("UNSUPPORTED_QUERY", "query", "The fake Square server only supports searching by object_types.")
+	}
+
+	limit := s.PageSize
+	if req.Limit > 0 && req.Limit < limit {
+		limit = req.Limit
+	}
+
+	objects, next, err := s.page(req.ObjectTypes, req.Cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"objects":     objects,
+		"cursor":      next,
+		"latest_time": time.Now().UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// Returns one page of stored objects of the specified types (all top-level types if none are
+// specified) starting at cursor, along with the cursor for the next page.
+func (s *Server) page(types []string, cursor string, size int) ([]interface{}, string, error) {
+	start := 0
+	if cursor != "" {
+		n, err := strconv.Atoi(cursor)
+		if err != nil || n < 0 {
+			return nil, "", invalidRequest("INVALID_CURSOR", "cursor", "The pagination cursor `%s` is invalid.", cursor)
+		}
+		start = n
+	}
+
+	matches := func(typ string) bool {
+		if len(types) == 0 {
+			for _, parent := range parentTypes {
+				if parent.childType == typ {
+					return false
+				}
+			}
+			return true
+		}
+
+		for _, t := range types {
+			if strings.EqualFold(t, typ) {
+				return true
+			}
+		}
+		return false
+	}
+
+	objects := []interface{}{}
+	i := 0
+	for _, id := range s.order {
+		if !matches(s.objects[id]["type"].(string)) {
+			continue
+		}
+
+		if i >= start && len(objects) < size {
+			objects = append(objects, s.render(id))
+		}
+		i++
+	}
+
+	next := ""
+	if start+len(objects) < i {
+		next = strconv.Itoa(start + len(objects))
+	}
+
+	return objects, next, nil
+}
+
+// Upserts a set of objects that may refer to one another by temporary ID. Either every object
+// is written or, if any is invalid, none is. Returns the mapping of temporary to permanent IDs.
+func (s *Server) upsertAtomically(objs []interface{}, field func(int) string) (map[string]string, error) {
+	snapshot := s.snapshot()
+	ids := map[string]string{}
+
+	// Assign permanent IDs to every new object (and new nested child) first, so that objects
+	// can refer to each other regardless of the order they appear in.
+	var assign func(interface{})
+	assign = func(o interface{}) {
+		obj, ok := o.(map[string]interface{})
+		if !ok {
+			return
+		}
+
+		if id, _ := obj["id"].(string); strings.HasPrefix(id, "#") {
+			if _, ok := ids[id]; !ok {
+				ids[id] = s.newID()
+			}
+		}
+
+		for _, child := range children(obj) {
+			assign(child)
+		}
+	}
+	for _, o := range objs {
+		assign(o)
+	}
+
+	for i, o := range objs {
+		obj, ok := o.(map[string]interface{})
+		if !ok {
+			s.restore(snapshot)
+			return nil, invalidRequest("INVALID_VALUE", field(i), "Expected an object")
+		}
+
+		if err := s.upsert(deepCopy(obj), ids, field(i)); err != nil {
+			s.restore(snapshot)
+			return nil, err
+		}
+	}
+
+	return ids, nil
+}
+
+// Validates and stores a single object and its nested children.
+func (s *Server) upsert(obj Object, ids map[string]string, field string) error {
+	replaceTempIDs(obj, ids)
+
+	typ, _ := obj["type"].(string)
+	if typ == "" {
+		return invalidRequest("MISSING_REQUIRED_PARAMETER", field+".type", "Field must be set")
+	}
+
+	id, _ := obj["id"].(string)
+	if id == "" {
+		return invalidRequest("MISSING_REQUIRED_PARAMETER", field+".id", "Field must be set")
+	}
+
+	dataField := strings.ToLower(typ) + "_data"
+	if typ == "ITEM_OPTION_VAL" {
+		dataField = "item_option_value_data"
+	}
+	data, ok := obj[dataField].(map[string]interface{})
+	if !ok {
+		return invalidRequest("MISSING_REQUIRED_PARAMETER", field+"."+dataField, "An object of type %s must have %s", typ, dataField)
+	}
+
+	existing, exists := s.objects[id]
+	wasTemp := false
+	for tempID, permanentID := range ids {
+		if permanentID == id && tempID != id {
+			wasTemp = true
+		}
+	}
+
+	if !exists && !wasTemp {
+		return notFound(id)
+	}
+
+	if exists {
+		if existing["type"] != typ {
+			return invalidRequest("INVALID_VALUE", field+".type", "Object `%s` is a %s, not a %s.", id, existing["type"], typ)
+		}
+
+		if v, ok := obj["version"].(float64); ok && int64(v) != versionOf(existing) {
+			return invalidRequest("VERSION_MISMATCH", field+".version", "Object version does not match latest database version.")
+		}
+	}
+
+	for _, f := range []string{"present_at_location_ids", "absent_at_location_ids"} {
+		ids, _ := obj[f].([]interface{})
+		for i, locationID := range ids {
+			if !s.hasLocation(locationID) {
+				return invalidRequest("INVALID_VALUE", fmt.Sprintf("%s.%s[%d]", field, f, i), "Location `%v` does not exist.", locationID)
+			}
+		}
+	}
+
+	if name, ok := data["name"].(string); ok && len(name) > 255 {
+		return invalidRequest("VALUE_TOO_LONG", field+"."+dataField+".name", "Field must be at most 255 characters long.")
+	}
+
+	for parentType, parent := range parentTypes {
+		if parent.childType != typ {
+			continue
+		}
+
+		parentID, _ := data[parent.parentRef].(string)
+		if p, ok := s.objects[parentID]; !ok || p["type"] != parentType {
+			return invalidRequest("INVALID_VALUE", field+"."+dataField+"."+parent.parentRef, "Object refers to a %s `%s` that does not exist.", parentType, parentID)
+		}
+	}
+
+	parent, isParent := parentTypes[typ]
+	var kids []interface{}
+	replaceKids := false
+	if isParent {
+		if v, ok := data[parent.childField]; ok && v != nil {
+			kids, _ = v.([]interface{})
+			replaceKids = true
+		}
+		delete(data, parent.childField)
+	}
+
+	if typ == "ITEM_VARIATION" {
+		if err := s.checkItemOptionValues(data, field+".item_variation_data.item_option_values"); err != nil {
+			return err
+		}
+
+		if unitID, ok := data["measurement_unit_id"].(string); ok && unitID != "" {
+			if u, ok := s.objects[unitID]; !ok || u["type"] != "MEASUREMENT_UNIT" {
+				return invalidRequest("INVALID_VALUE", field+".item_variation_data.measurement_unit_id", "Object refers to a MEASUREMENT_UNIT `%s` that does not exist.", unitID)
+			}
+		}
+
+		overridden := map[interface{}]bool{}
+		overrides, _ := data["location_overrides"].([]interface{})
+		for i, o := range overrides {
+			override, _ := o.(map[string]interface{})
+			locationField := fmt.Sprintf("%s.item_variation_data.location_overrides[%d].location_id", field, i)
+			if !s.hasLocation(override["location_id"]) {
+				return invalidRequest("INVALID_VALUE", locationField, "Location `%v` does not exist.", override["location_id"])
+			}
+			if overridden[override["location_id"]] {
+				return invalidRequest("INVALID_VALUE", locationField, "Location `%v` is overridden more than once.", override["location_id"])
+			}
+			overridden[override["location_id"]] = true
+		}
+
+		item, _ := s.objects[fmt.Sprint(data["item_id"])]["item_data"].(map[string]interface{})
+		if item["product_type"] != "APPOINTMENTS_SERVICE" {
+			for _, f := range []string{"available_for_booking", "no_show_fee", "service_duration", "team_member_ids"} {
+				if _, ok := data[f]; ok {
+					return invalidRequest("INVALID_VALUE", field+".item_variation_data."+f, "Only a variation of an APPOINTMENTS_SERVICE item can have %s.", f)
+				}
+			}
+		}
+
+		if data["sellable"] == false && data["stockable"] == false {
+			return invalidRequest("INVALID_VALUE", field+".item_variation_data", "An item variation must be sellable or stockable.")
+		}
+	}
+
+	// An image's URL is set by its upload and survives updates of its caption and name.
+	if typ == "IMAGE" && exists {
+		if existingData, ok := existing["image_data"].(map[string]interface{}); ok {
+			setDefault(data, "url", existingData["url"])
+		}
+	}
+
+	// Like Square, treat omitted modifier list settings as enabled with no selection limits.
+	if infos, ok := data["modifier_list_info"].([]interface{}); ok {
+		for _, i := range infos {
+			if info, ok := i.(map[string]interface{}); ok {
+				setDefault(info, "enabled", true)
+				setDefault(info, "min_selected_modifiers", -1)
+				setDefault(info, "max_selected_modifiers", -1)
+			}
+		}
+	}
+
+	if typ == "PRODUCT_SET" {
+		set := 0
+		for _, f := range []string{"all_products", "product_ids_all", "product_ids_any"} {
+			if v, ok := data[f]; ok && v != nil && v != false {
+				set++
+			}
+		}
+		if set > 1 {
+			return invalidRequest("INVALID_VALUE", field+".product_set_data", "Only one of product_ids_all, product_ids_any, or all_products can be set.")
+		}
+	}
+
+	if typ == "PRICING_RULE" {
+		setDefault(data, "exclude_strategy", "LEAST_EXPENSIVE")
+	}
+
+	if typ == "ITEM" {
+		if err := s.checkItem(data, field+".item_data"); err != nil {
+			return err
+		}
+
+		setDefault(data, "product_type", "REGULAR")
+		setDefault(data, "is_taxable", true)
+		setDefault(data, "ecom_visibility", "UNINDEXED")
+		if html, ok := data["description_html"].(string); ok {
+			data["description"] = htmlTags.ReplaceAllString(html, "")
+		}
+	}
+
+	if _, ok := obj["present_at_all_locations"]; !ok {
+		obj["present_at_all_locations"] = true
+	}
+	s.store(obj)
+
+	if replaceKids {
+		keep := map[string]bool{}
+		for i, k := range kids {
+			kid, ok := k.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			kidData, _ := kid[strings.ToLower(parent.childType)+"_data"].(map[string]interface{})
+			if parent.childType == "ITEM_OPTION_VAL" {
+				kidData, _ = kid["item_option_value_data"].(map[string]interface{})
+			}
+			if kidData != nil {
+				kidData[parent.parentRef] = id
+			}
+
+			childField := fmt.Sprintf("%s.%s.%s[%d]", field, dataField, parent.childField, i)
+			if err := s.upsert(kid, ids, childField); err != nil {
+				return err
+			}
+			keep[resolveID(kid, ids)] = true
+		}
+
+		for _, childID := range s.childrenOf(id) {
+			if !keep[childID] {
+				s.delete(childID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validates the references and product type specific details of an item.
+func (s *Server) checkItem(data map[string]interface{}, field string) error {
+	if _, ok := data["food_and_beverage_details"]; ok && data["product_type"] != "FOOD_AND_BEV" {
+		return invalidRequest("INVALID_VALUE", field+".food_and_beverage_details", "Only an item of product type FOOD_AND_BEV can have food and beverage details.")
+	}
+
+	if category, ok := data["reporting_category"].(map[string]interface{}); ok {
+		categoryID := fmt.Sprint(category["id"])
+		if c, ok := s.objects[categoryID]; !ok || c["type"] != "CATEGORY" {
+			return invalidRequest("INVALID_VALUE", field+".reporting_category.id", "Object refers to a CATEGORY `%s` that does not exist.", categoryID)
+		}
+	}
+
+	imageIDs, _ := data["image_ids"].([]interface{})
+	for i, imageID := range imageIDs {
+		if img, ok := s.objects[fmt.Sprint(imageID)]; !ok || img["type"] != "IMAGE" {
+			return invalidRequest("INVALID_VALUE", fmt.Sprintf("%s.image_ids[%d]", field, i), "Object refers to an IMAGE `%v` that does not exist.", imageID)
+		}
+	}
+
+	return nil
+}
+
+// Reports whether a location with the specified ID exists.
+func (s *Server) hasLocation(id interface{}) bool {
+	for _, l := range s.locations {
+		if l["id"] == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validates that every option value of a variation belongs to one of its item's options.
+func (s *Server) checkItemOptionValues(data map[string]interface{}, field string) error {
+	values, _ := data["item_option_values"].([]interface{})
+	if len(values) == 0 {
+		return nil
+	}
+
+	options := map[string]bool{}
+	if item, ok := s.objects[fmt.Sprint(data["item_id"])]; ok {
+		itemData, _ := item["item_data"].(map[string]interface{})
+		itemOptions, _ := itemData["item_options"].([]interface{})
+		for _, o := range itemOptions {
+			if option, ok := o.(map[string]interface{}); ok {
+				options[fmt.Sprint(option["item_option_id"])] = true
+			}
+		}
+	}
+
+	for i, v := range values {
+		value, _ := v.(map[string]interface{})
+		optionID := fmt.Sprint(value["item_option_id"])
+		if !options[optionID] {
+			return invalidRequest("INVALID_VALUE", fmt.Sprintf("%s[%d].item_option_id", field, i), "Item option `%s` is not an option of the variation's item.", optionID)
+		}
+
+		valueID := fmt.Sprint(value["item_option_value_id"])
+		obj, ok := s.objects[valueID]
+		valueData, _ := obj["item_option_value_data"].(map[string]interface{})
+		if !ok || obj["type"] != "ITEM_OPTION_VAL" || valueData["item_option_id"] != optionID {
+			return invalidRequest("INVALID_VALUE", fmt.Sprintf("%s[%d].item_option_value_id", field, i), "Item option value `%s` is not a value of item option `%s`.", valueID, optionID)
+		}
+	}
+
+	return nil
+}
+
+// Stores an object under a new version.
+func (s *Server) store(obj Object) {
+	id := obj["id"].(string)
+	if _, ok := s.objects[id]; !ok {
+		s.order = append(s.order, id)
+	}
+
+	s.version++
+	obj["version"] = s.version
+	obj["updated_at"] = time.Now().UTC().Format(time.RFC3339)
+	obj["is_deleted"] = false
+	s.objects[id] = obj
+}
+
+// Deletes an object and its children, returning the IDs of everything deleted.
+func (s *Server) delete(id string) []string {
+	if _, ok := s.objects[id]; !ok {
+		return nil
+	}
+
+	deleted := []string{id}
+	for _, childID := range s.childrenOf(id) {
+		deleted = append(deleted, s.delete(childID)...)
+	}
+
+	delete(s.objects, id)
+	delete(s.images, id)
+	for _, obj := range s.objects {
+		if obj["image_id"] == id {
+			delete(obj, "image_id")
+		}
+		if itemData, ok := obj["item_data"].(map[string]interface{}); ok {
+			if imageIDs, ok := itemData["image_ids"].([]interface{}); ok {
+				kept := []interface{}{}
+				for _, imageID := range imageIDs {
+					if imageID != id {
+						kept = append(kept, imageID)
+					}
+				}
+				itemData["image_ids"] = kept
+			}
+		}
+	}
+
+	for i, oid := range s.order {
+		if oid == id {
+			s.order = append(s.order[:i:i], s.order[i+1:]...)
+			break
+		}
+	}
+
+	return deleted
+}
+
+// Returns the IDs of the stored children of a parent object, ordered by ordinal and then by
+// creation order.
+func (s *Server) childrenOf(id string) []string {
+	parent, ok := parentTypes[typeOf(s.objects[id])]
+	if !ok {
+		return nil
+	}
+
+	kids := []string{}
+	for _, oid := range s.order {
+		obj := s.objects[oid]
+		if typeOf(obj) != parent.childType {
+			continue
+		}
+
+		if data, ok := obj[dataFieldOf(obj)].(map[string]interface{}); ok && data[parent.parentRef] == id {
+			kids = append(kids, oid)
+		}
+	}
+
+	sort.SliceStable(kids, func(i, j int) bool {
+		return ordinalOf(s.objects[kids[i]]) < ordinalOf(s.objects[kids[j]])
+	})
+
+	return kids
+}
+
+// Returns a copy of a stored object as Square would return it, with nested children attached.
+func (s *Server) render(id string) Object {
+	obj := deepCopy(s.objects[id])
+	if parent, ok := parentTypes[typeOf(obj)]; ok {
+		kids := []interface{}{}
+		for _, childID := range s.childrenOf(id) {
+			kids = append(kids, s.render(childID))
+		}
+
+		if data, ok := obj[dataFieldOf(obj)].(map[string]interface{}); ok {
+			data[parent.childField] = kids
+		}
+	}
+
+	return obj
+}
+
+func (s *Server) newID() string {
+	s.nextID++
+	return fmt.Sprintf("FAKE%022d", s.nextID)
+}
+
+// snapshot is a copy of the catalog taken before a write, which is restored if the write fails.
+type snapshot struct {
+	objects map[string]Object
+	order   []string
+	version int64
+}
+
+// Returns a copy of the catalog. Writes modify stored objects in place (deleting an image removes
+// it from the items that show it), so each object is copied too.
+func (s *Server) snapshot() snapshot {
+	objects := make(map[string]Object, len(s.objects))
+	for id, obj := range s.objects {
+		objects[id] = deepCopy(obj)
+	}
+
+	return snapshot{objects: objects, order: append([]string{}, s.order...), version: s.version}
+}
+
+func (s *Server) restore(snap snapshot) {
+	s.objects, s.order, s.version = snap.objects, snap.order, snap.version
+}
+
+// Returns the nested children of an object being upserted.
+func children(obj map[string]interface{}) []interface{} {
+	parent, ok := parentTypes[typeOf(obj)]
+	if !ok {
+		return nil
+	}
+
+	data, _ := obj[dataFieldOf(obj)].(map[string]interface{})
+	kids, _ := data[parent.childField].([]interface{})
+	return kids
+}
+
+// Replaces every string value in v that is a temporary ID with the permanent ID it maps to.
+func replaceTempIDs(v interface{}, ids map[string]string) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			if str, ok := child.(string); ok {
+				if id, ok := ids[str]; ok {
+					val[k] = id
+				}
+				continue
+			}
+			replaceTempIDs(child, ids)
+		}
+	case []interface{}:
+		for i, child := range val {
+			if str, ok := child.(string); ok {
+				if id, ok := ids[str]; ok {
+					val[i] = id
+				}
+				continue
+			}
+			replaceTempIDs(child, ids)
+		}
+	}
+}
+
+func resolveID(obj map[string]interface{}, ids map[string]string) string {
+	id, _ := obj["id"].(string)
+	if permanent, ok := ids[id]; ok {
+		return permanent
+	}
+	return id
+}
+
+func idMappings(ids map[string]string) []interface{} {
+	tempIDs := make([]string, 0, len(ids))
+	for tempID := range ids {
+		tempIDs = append(tempIDs, tempID)
+	}
+	sort.Strings(tempIDs)
+
+	mappings := []interface{}{}
+	for _, tempID := range tempIDs {
+		mappings = append(mappings, map[string]interface{}{"client_object_id": tempID, "object_id": ids[tempID]})
+	}
+	return mappings
+}
+
+func typeOf(obj Object) string {
+	typ, _ := obj["type"].(string)
+	return typ
+}
+
+func dataFieldOf(obj Object) string {
+	if typeOf(obj) == "ITEM_OPTION_VAL" {
+		return "item_option_value_data"
+	}
+	return strings.ToLower(typeOf(obj)) + "_data"
+}
+
+func versionOf(obj Object) int64 {
+	switch v := obj["version"].(type) {
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
+
+func ordinalOf(obj Object) float64 {
+	data, _ := obj[dataFieldOf(obj)].(map[string]interface{})
+	ordinal, _ := data["ordinal"].(float64)
+	return ordinal
+}
+
+func deepCopy(obj Object) Object {
+	b, _ := json.Marshal(obj)
+	var cp Object
+	json.Unmarshal(b, &cp)
+	return cp
+}
+
+// Sets a field of a JSON object unless it is already present.
+func setDefault(m map[string]interface{}, field string, value interface{}) {
+	if _, ok := m[field]; !ok {
+		m[field] = value
+	}
+}
@@ -0,0 +1,361 @@
+package squaretest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Sends a request to the server, returning the status and decoded body of its response.
+func send(t *testing.T, s *Server, method, path string, body interface{}) (int, map[string]interface{}) {
+	t.Helper()
+
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req, err := http.NewRequest(method, s.URL+path, &reqBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer token")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var decoded map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	return resp.StatusCode, decoded
+}
+
+// Upserts an object with a new idempotency key, failing the test unless it succeeds.
+func mustUpsert(t *testing.T, s *Server, obj Object) Object {
+	t.Helper()
+
+	status, resp := send(t, s, http.MethodPost, "/v2/catalog/object", map[string]interface{}{
+		"idempotency_key": fmt.Sprintf("upsert-%d", len(s.Requests())),
+		"object":          obj,
+	})
+	if status != http.StatusOK {
+		t.Fatalf("upsert returned %d: %v", status, resp["errors"])
+	}
+
+	return resp["catalog_object"].(map[string]interface{})
+}
+
+// Returns the code and field of the single error in a Square error response.
+func errorOf(t *testing.T, resp map[string]interface{}) (string, string) {
+	t.Helper()
+
+	errs, _ := resp["errors"].([]interface{})
+	if len(errs) != 1 {
+		t.Fatalf("response has errors %v, want one", resp["errors"])
+	}
+
+	e := errs[0].(map[string]interface{})
+	field, _ := e["field"].(string)
+	return e["code"].(string), field
+}
+
+func category(id, name string) Object {
+	return Object{"id": id, "type": "CATEGORY", "category_data": map[string]interface{}{"name": name}}
+}
+
+func TestServer_versions(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	created := mustUpsert(t, s, category("#category", "Apparel"))
+	id, version := created["id"].(string), created["version"].(float64)
+
+	update := category(id, "Clothing")
+	update["version"] = version
+	updated := mustUpsert(t, s, update)
+	if updated["version"].(float64) <= version {
+		t.Errorf("update has version %v, want one after %v", updated["version"], version)
+	}
+
+	// The update read the version the object had before the last one.
+	stale := category(id, "Outerwear")
+	stale["version"] = version
+	status, resp := send(t, s, http.MethodPost, "/v2/catalog/object", map[string]interface{}{
+		"idempotency_key": "stale",
+		"object":          stale,
+	})
+	if code, _ := errorOf(t, resp); status != http.StatusBadRequest || code != "VERSION_MISMATCH" {
+		t.Errorf("stale update returned %d %s, want 400 VERSION_MISMATCH", status, code)
+	}
+
+	if obj, _ := s.Object(id); obj["category_data"].(map[string]interface{})["name"] != "Clothing" {
+		t.Errorf("stale update changed the category to %v", obj["category_data"])
+	}
+
+	if !s.UpdateObject(id, func(obj Object) {}) {
+		t.Fatal("UpdateObject did not find the category")
+	}
+	if obj, _ := s.Object(id); obj["version"].(float64) <= updated["version"].(float64) {
+		t.Errorf("change outside of the API left the version at %v", obj["version"])
+	}
+}
+
+func TestServer_tempIDs(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	status, resp := send(t, s, http.MethodPost, "/v2/catalog/batch-upsert", map[string]interface{}{
+		"idempotency_key": "key",
+		"batches": []interface{}{map[string]interface{}{"objects": []interface{}{
+			Object{"id": "#shirt", "type": "ITEM", "item_data": map[string]interface{}{
+				"name": "T-shirt",
+				"variations": []interface{}{
+					Object{"id": "#small", "type": "ITEM_VARIATION", "item_variation_data": map[string]interface{}{
+						"name": "Small", "pricing_type": "VARIABLE_PRICING",
+					}},
+				},
+			}},
+			// A standalone variation refers to the item by its temporary ID.
+			Object{"id": "#large", "type": "ITEM_VARIATION", "item_variation_data": map[string]interface{}{
+				"item_id": "#shirt", "name": "Large", "pricing_type": "VARIABLE_PRICING",
+			}},
+		}}},
+	})
+	if status != http.StatusOK {
+		t.Fatalf("batch upsert returned %d: %v", status, resp["errors"])
+	}
+
+	ids := map[string]string{}
+	for _, m := range resp["id_mappings"].([]interface{}) {
+		mapping := m.(map[string]interface{})
+		ids[mapping["client_object_id"].(string)] = mapping["object_id"].(string)
+	}
+	for _, tempID := range []string{"#shirt", "#small", "#large"} {
+		if id := ids[tempID]; id == "" || strings.HasPrefix(id, "#") {
+			t.Errorf("%s was mapped to %q, want a permanent ID", tempID, id)
+		}
+	}
+
+	item, ok := s.Object(ids["#shirt"])
+	if !ok {
+		t.Fatal("item was not created")
+	}
+
+	variations := item["item_data"].(map[string]interface{})["variations"].([]interface{})
+	if len(variations) != 2 {
+		t.Fatalf("item has %d variations, want 2", len(variations))
+	}
+	for _, v := range variations {
+		data := v.(map[string]interface{})["item_variation_data"].(map[string]interface{})
+		if data["item_id"] != ids["#shirt"] {
+			t.Errorf("variation %s refers to item %v, want %s", data["name"], data["item_id"], ids["#shirt"])
+		}
+	}
+}
+
+func TestServer_idempotency(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	req := map[string]interface{}{"idempotency_key": "key", "object": category("#category", "Apparel")}
+	_, first := send(t, s, http.MethodPost, "/v2/catalog/object", req)
+	status, second := send(t, s, http.MethodPost, "/v2/catalog/object", req)
+	if status != http.StatusOK {
+		t.Fatalf("repeated request returned %d: %v", status, second["errors"])
+	}
+
+	firstID := first["catalog_object"].(map[string]interface{})["id"]
+	if secondID := second["catalog_object"].(map[string]interface{})["id"]; secondID != firstID {
+		t.Errorf("repeated request created %v after %v, want the first response replayed", secondID, firstID)
+	}
+
+	_, list := send(t, s, http.MethodGet, "/v2/catalog/list", nil)
+	if n := len(list["objects"].([]interface{})); n != 1 {
+		t.Errorf("catalog has %d objects, want 1", n)
+	}
+
+	status, resp := send(t, s, http.MethodPost, "/v2/catalog/object", map[string]interface{}{
+		"idempotency_key": "key",
+		"object":          category("#category", "Clothing"),
+	})
+	if code, _ := errorOf(t, resp); status != http.StatusBadRequest || code != "IDEMPOTENCY_KEY_REUSED" {
+		t.Errorf("reused key returned %d %s, want 400 IDEMPOTENCY_KEY_REUSED", status, code)
+	}
+}
+
+func TestServer_pagination(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.PageSize = 2
+
+	for i := 0; i < 5; i++ {
+		mustUpsert(t, s, category("#category", fmt.Sprintf("Category %d", i)))
+	}
+
+	names := []string{}
+	pages := 0
+	for cursor := ""; ; {
+		status, resp := send(t, s, http.MethodGet, "/v2/catalog/list?types=CATEGORY&cursor="+cursor, nil)
+		if status != http.StatusOK {
+			t.Fatalf("list returned %d: %v", status, resp["errors"])
+		}
+
+		pages++
+		for _, o := range resp["objects"].([]interface{}) {
+			names = append(names, o.(map[string]interface{})["category_data"].(map[string]interface{})["name"].(string))
+		}
+
+		if cursor, _ = resp["cursor"].(string); cursor == "" {
+			break
+		}
+	}
+
+	if pages != 3 {
+		t.Errorf("listed %d pages, want 3", pages)
+	}
+	if want := "Category 0,Category 1,Category 2,Category 3,Category 4"; strings.Join(names, ",") != want {
+		t.Errorf("listed %v, want %s", names, want)
+	}
+
+	status, resp := send(t, s, http.MethodGet, "/v2/catalog/list?cursor=bogus", nil)
+	if code, _ := errorOf(t, resp); status != http.StatusBadRequest || code != "INVALID_CURSOR" {
+		t.Errorf("bad cursor returned %d %s, want 400 INVALID_CURSOR", status, code)
+	}
+}
+
+func TestServer_atomicBatch(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	item := mustUpsert(t, s, Object{"id": "#shirt", "type": "ITEM", "item_data": map[string]interface{}{
+		"name": "T-shirt",
+		"variations": []interface{}{
+			Object{"id": "#small", "type": "ITEM_VARIATION", "item_variation_data": map[string]interface{}{
+				"name": "Small", "pricing_type": "VARIABLE_PRICING",
+			}},
+		},
+	}})
+	itemID := item["id"].(string)
+
+	// The item's update removes its variation, but the batch is rejected by the object after it.
+	status, resp := send(t, s, http.MethodPost, "/v2/catalog/batch-upsert", map[string]interface{}{
+		"idempotency_key": "key",
+		"batches": []interface{}{map[string]interface{}{"objects": []interface{}{
+			Object{"id": itemID, "type": "ITEM", "item_data": map[string]interface{}{
+				"name":       "Polo shirt",
+				"variations": []interface{}{},
+			}},
+			category("#category", "Apparel"),
+			Object{"id": "#large", "type": "ITEM_VARIATION", "item_variation_data": map[string]interface{}{
+				"item_id": "MISSING", "name": "Large", "pricing_type": "VARIABLE_PRICING",
+			}},
+		}}},
+	})
+	if code, field := errorOf(t, resp); status != http.StatusBadRequest || field != "batches[0].objects[2].item_variation_data.item_id" {
+		t.Fatalf("batch returned %d %s for %s, want 400 for the variation's item_id", status, code, field)
+	}
+
+	after, _ := s.Object(itemID)
+	data := after["item_data"].(map[string]interface{})
+	if data["name"] != "T-shirt" || len(data["variations"].([]interface{})) != 1 || after["version"] != item["version"] {
+		t.Errorf("item is %v after a rejected batch, want it unchanged", after)
+	}
+
+	_, list := send(t, s, http.MethodGet, "/v2/catalog/list?types=CATEGORY", nil)
+	if n := len(list["objects"].([]interface{})); n != 0 {
+		t.Errorf("rejected batch created %d categories", n)
+	}
+}
+
+func TestServer_validation(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddLocation("LOCATION1", "Main Street")
+
+	item := mustUpsert(t, s, Object{"id": "#item", "type": "ITEM", "item_data": map[string]interface{}{"name": "T-shirt"}})
+	variation := func(data map[string]interface{}) Object {
+		data["item_id"] = item["id"]
+		data["name"] = "Large"
+		data["pricing_type"] = "VARIABLE_PRICING"
+		return Object{"id": "#variation", "type": "ITEM_VARIATION", "item_variation_data": data}
+	}
+
+	for _, tc := range []struct {
+		name  string
+		obj   Object
+		code  string
+		field string
+	}{
+		{
+			"missing type",
+			Object{"id": "#category", "category_data": map[string]interface{}{"name": "Apparel"}},
+			"MISSING_REQUIRED_PARAMETER", "object.type",
+		},
+		{
+			"missing data",
+			Object{"id": "#category", "type": "CATEGORY"},
+			"MISSING_REQUIRED_PARAMETER", "object.category_data",
+		},
+		{
+			"unknown ID",
+			category("MISSING", "Apparel"),
+			"NOT_FOUND", "",
+		},
+		{
+			"name too long",
+			category("#category", strings.Repeat("x", 256)),
+			"VALUE_TOO_LONG", "object.category_data.name",
+		},
+		{
+			"unknown location",
+			Object{"id": "#category", "type": "CATEGORY", "present_at_location_ids": []interface{}{"MISSING"}, "category_data": map[string]interface{}{"name": "Apparel"}},
+			"INVALID_VALUE", "object.present_at_location_ids[0]",
+		},
+		{
+			"wrong type of parent",
+			Object{"id": "#modifier", "type": "MODIFIER", "modifier_data": map[string]interface{}{"name": "Cheese", "modifier_list_id": item["id"]}},
+			"INVALID_VALUE", "object.modifier_data.modifier_list_id",
+		},
+		{
+			"neither sellable nor stockable",
+			variation(map[string]interface{}{"sellable": false, "stockable": false}),
+			"INVALID_VALUE", "object.item_variation_data",
+		},
+		{
+			"service details of a regular item",
+			variation(map[string]interface{}{"service_duration": 3600000}),
+			"INVALID_VALUE", "object.item_variation_data.service_duration",
+		},
+		{
+			"location overridden twice",
+			variation(map[string]interface{}{"location_overrides": []interface{}{
+				map[string]interface{}{"location_id": "LOCATION1"},
+				map[string]interface{}{"location_id": "LOCATION1"},
+			}}),
+			"INVALID_VALUE", "object.item_variation_data.location_overrides[1].location_id",
+		},
+	} {
+		status, resp := send(t, s, http.MethodPost, "/v2/catalog/object", map[string]interface{}{
+			"idempotency_key": tc.name,
+			"object":          tc.obj,
+		})
+		wantStatus := http.StatusBadRequest
+		if tc.code == "NOT_FOUND" {
+			wantStatus = http.StatusNotFound
+		}
+
+		if code, field := errorOf(t, resp); status != wantStatus || code != tc.code || field != tc.field {
+			t.Errorf("%s: got %d %s for %q, want %d %s for %q", tc.name, status, code, field, wantStatus, tc.code, tc.field)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package square
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/jefflinse/terraform-provider-square/square/client"
+)
+
+// Rewrites the errors Square reported for a write so that each one names the resource attribute
+// it refers to (e.g. "name: VALUE_TOO_LONG: ...") rather than Square's request field path.
+// Square fields with no obvious attribute counterpart are translated using fields, which maps
+// field paths relative to the object's data (e.g. "price_money.amount") to attribute names. A
+// list of nested objects in fields (e.g. "variations") maps to the block configuring them, so an
+// error in a nested object names the block's attribute (e.g. "variation.1.price"), translated
+// using fields too. A *client.ConflictError is returned unchanged, as it explains the conflict
+// itself.
+func attributeErrors(err error, fields map[string]string) error {
+	var conflictErr *client.ConflictError
+	if errors.As(err, &conflictErr) {
+		return err
+	}
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || len(apiErr.Errors) == 0 {
+		return err
+	}
+
+	var result *multierror.Error
+	for _, e := range apiErr.Errors {
+		msg := e.Code
+		if e.Detail != "" {
+			msg = fmt.Sprintf("%s: %s", msg, e.Detail)
+		}
+
+		if e.Field != "" {
+			msg = fmt.Sprintf("%s: %s", attributeForField(e.Field, fields), msg)
+		}
+
+		result = multierror.Append(result, errors.New(msg))
+	}
+
+	return result.ErrorOrNil()
+}
+
+// objectFieldPattern matches the part of a request field path locating the object written, e.g.
+// "object." or "batches[0].objects[1].".
+var objectFieldPattern = regexp.MustCompile(`^(batches\[\d+\]\.)?(object|objects\[\d+\])\.`)
+
+// nestedFieldPattern matches a field path into a nested object, e.g.
+// "variations[1].item_variation_data.name", capturing the list, the index, and the path within.
+var nestedFieldPattern = regexp.MustCompile(`^(\w+)\[(\d+)\]\.(.+)$`)
+
+// Translates a Square request field path such as "object.item_data.name" into an attribute name.
+func attributeForField(field string, fields map[string]string) string {
+	return attributeForObjectField(objectFieldPattern.ReplaceAllString(field, ""), fields)
+}
+
+// Translates a field path relative to a catalog object, such as "item_data.name", into an
+// attribute name.
+func attributeForObjectField(path string, fields map[string]string) string {
+	if i := strings.Index(path, "_data."); i >= 0 && !strings.Contains(path[:i], ".") {
+		path = path[i+len("_data."):]
+	}
+
+	if m := nestedFieldPattern.FindStringSubmatch(path); m != nil {
+		if block, ok := fields[m[1]]; ok {
+			return fmt.Sprintf("%s.%s.%s", block, m[2], attributeForObjectField(m[3], fields))
+		}
+	}
+
+	if attr, ok := fields[path]; ok {
+		return attr
+	}
+
+	return path
+}
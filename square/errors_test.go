@@ -0,0 +1,22 @@
+package square
+
+import "testing"
+
+func TestAttributeForField(t *testing.T) {
+	for _, tc := range []struct {
+		field string
+		want  string
+	}{
+		{"object.item_data.name", "name"},
+		{"object.present_at_location_ids[0]", "present_at_location_ids[0]"},
+		{"batches[0].objects[1].item_data.name", "name"},
+		{"object.item_data.variations[1].item_variation_data.name", "variation.1.name"},
+		{"objects[0].item_data.variations[1].item_variation_data.price_money", "variation.1.price_money"},
+		{"object.item_data.variations[0].item_variation_data.price_money.amount", "variation.0.price"},
+		{"object.item_data.variations[2].version", "variation.2.version"},
+	} {
+		if got := attributeForField(tc.field, catalogItemFields); got != tc.want {
+			t.Errorf("attributeForField(%q) = %q, want %q", tc.field, got, tc.want)
+		}
+	}
+}
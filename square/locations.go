@@ -0,0 +1,131 @@
+package square
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	squaremodel "github.com/jefflinse/square-connect/models"
+	"github.com/jefflinse/terraform-provider-square/square/client"
+)
+
+// Adds the attributes choosing the locations a catalog object is present at to a resource schema.
+// An object present at all locations can be made absent from some, and an object that is not can
+// be made present at some.
+func withLocationAttributes(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["absent_at_location_ids"] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+	s["present_at_all_locations"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  true,
+	}
+	s["present_at_location_ids"] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+
+	return s
+}
+
+// Sets the locations a catalog object is present at from the resource's attributes. Square makes
+// an object present at all locations unless told otherwise, so a false present_at_all_locations
+// is always sent for objects the provider manages.
+func expandCatalogObjectLocations(d *schema.ResourceData, obj *squaremodel.CatalogObject) *client.CatalogObject {
+	obj.AbsentAtLocationIds = []string{}
+	for _, id := range d.Get("absent_at_location_ids").(*schema.Set).List() {
+		obj.AbsentAtLocationIds = append(obj.AbsentAtLocationIds, id.(string))
+	}
+
+	obj.PresentAtAllLocations = d.Get("present_at_all_locations").(bool)
+
+	obj.PresentAtLocationIds = []string{}
+	for _, id := range d.Get("present_at_location_ids").(*schema.Set).List() {
+		obj.PresentAtLocationIds = append(obj.PresentAtLocationIds, id.(string))
+	}
+
+	expanded := client.NewCatalogObject(obj)
+	expanded.SetField("present_at_all_locations", obj.PresentAtAllLocations)
+	return expanded
+}
+
+func flattenCatalogObjectLocations(obj *client.CatalogObject, d *schema.ResourceData) {
+	d.Set("absent_at_location_ids", obj.AbsentAtLocationIds)
+	d.Set("present_at_all_locations", obj.PresentAtAllLocations)
+	d.Set("present_at_location_ids", obj.PresentAtLocationIds)
+}
+
+// Validates the locations a catalog object is present at: an object cannot be both present and
+// absent at a location, each list only means something for one value of present_at_all_locations,
+// and every location must exist.
+func validateCatalogObjectLocations(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("absent_at_location_ids.#") || !d.NewValueKnown("present_at_location_ids.#") {
+		return nil
+	}
+
+	absent := d.Get("absent_at_location_ids").(*schema.Set)
+	present := d.Get("present_at_location_ids").(*schema.Set)
+	if absent.Len() == 0 && present.Len() == 0 {
+		return nil
+	}
+
+	if both := sortedStrings(present.Intersection(absent)); len(both) > 0 {
+		return fmt.Errorf("locations cannot be in both present_at_location_ids and absent_at_location_ids: %s", strings.Join(both, ", "))
+	}
+
+	if d.NewValueKnown("present_at_all_locations") {
+		if all := d.Get("present_at_all_locations").(bool); all && present.Len() > 0 {
+			return fmt.Errorf("present_at_location_ids cannot be set when present_at_all_locations is true; use absent_at_location_ids to exclude locations")
+		} else if !all && absent.Len() > 0 {
+			return fmt.Errorf("absent_at_location_ids cannot be set when present_at_all_locations is false; use present_at_location_ids to choose locations")
+		}
+	}
+
+	return validateLocationsExist(meta, sortedStrings(present.Union(absent)))
+}
+
+// Validates that every one of a list of location IDs belongs to an existing location.
+func validateLocationsExist(meta interface{}, ids []string) error {
+	locations, err := meta.(client.SquareAPI).ListLocations()
+	if err != nil {
+		return err
+	}
+
+	exists := map[string]bool{}
+	for _, l := range locations {
+		exists[l.ID] = true
+	}
+
+	missing := []string{}
+	for _, id := range ids {
+		if !exists[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("locations do not exist: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// Returns the strings in a set, sorted.
+func sortedStrings(s *schema.Set) []string {
+	result := []string{}
+	for _, v := range s.List() {
+		result = append(result, v.(string))
+	}
+	sort.Strings(result)
+
+	return result
+}
@@ -0,0 +1,88 @@
+package square
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSquareCatalogObject_locations(t *testing.T) {
+	first, second := testAccLocationIDs(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_category"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogObjectLocationsConfig(true, nil, []string{first}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_category.test"),
+					resource.TestCheckResourceAttr("square_catalog_category.test", "present_at_all_locations", "true"),
+					resource.TestCheckResourceAttr("square_catalog_category.test", "absent_at_location_ids.#", "1"),
+					resource.TestCheckResourceAttr("square_catalog_category.test", "present_at_location_ids.#", "0"),
+				),
+			},
+			{
+				Config: testAccSquareCatalogObjectLocationsConfig(false, []string{first, second}, nil),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_catalog_category.test", "present_at_all_locations", "false"),
+					resource.TestCheckResourceAttr("square_catalog_category.test", "absent_at_location_ids.#", "0"),
+					resource.TestCheckResourceAttr("square_catalog_category.test", "present_at_location_ids.#", "2"),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_category.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+			{
+				Config: testAccSquareCatalogObjectLocationsConfig(true, nil, nil),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_catalog_category.test", "present_at_all_locations", "true"),
+					resource.TestCheckResourceAttr("square_catalog_category.test", "present_at_location_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogObject_invalidLocations(t *testing.T) {
+	first, _ := testAccLocationIDs(t)
+
+	testAccRunRejectedConfigs(t, []testAccRejectedConfig{
+		{
+			name:   "present and absent",
+			config: testAccSquareCatalogObjectLocationsConfig(false, []string{first}, []string{first}),
+			err:    "cannot be in both present_at_location_ids and absent_at_location_ids: " + first,
+		},
+		{
+			name:   "present at all and listed",
+			config: testAccSquareCatalogObjectLocationsConfig(true, []string{first}, nil),
+			err:    "present_at_location_ids cannot be set when present_at_all_locations is true",
+		},
+		{
+			name:   "absent without present at all",
+			config: testAccSquareCatalogObjectLocationsConfig(false, nil, []string{first}),
+			err:    "absent_at_location_ids cannot be set when present_at_all_locations is false",
+		},
+		{
+			name:   "unknown location",
+			config: testAccSquareCatalogObjectLocationsConfig(true, nil, []string{"NOSUCHLOCATION"}),
+			err:    "locations do not exist: NOSUCHLOCATION",
+		},
+	})
+}
+
+func testAccSquareCatalogObjectLocationsConfig(all bool, present, absent []string) string {
+	return fmt.Sprintf(`
+resource "square_catalog_category" "test" {
+  name                     = "Seasonal"
+  present_at_all_locations = %t
+  present_at_location_ids  = %s
+  absent_at_location_ids   = %s
+}
+`, all, testAccStringList(present), testAccStringList(absent))
+}
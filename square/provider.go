@@ -3,23 +3,115 @@ package square
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/jefflinse/terraform-provider-square/square/client"
 )
 
-const squarerAPIAccessTokenEnvVar = "SQUARE_API_ACCESS_TOKEN"
+const (
+	squareAPIAccessTokenEnvVar = "SQUARE_API_ACCESS_TOKEN"
+	squareBaseURLEnvVar        = "SQUARE_BASE_URL"
+	squareCassetteEnvVar       = "SQUARE_CASSETTE"
+	squareCassetteModeEnvVar   = "SQUARE_CASSETTE_MODE"
+	squareEnvironmentEnvVar    = "SQUARE_ENVIRONMENT"
+	squareVersionEnvVar        = "SQUARE_VERSION"
+)
 
 // Provider returns the ResourceProvider.
 func Provider() terraform.ResourceProvider {
 	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"access_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc(squareAPIAccessTokenEnvVar, nil),
+				Description: "The Square access token used to authenticate API requests.",
+			},
+			"base_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc(squareBaseURLEnvVar, ""),
+				Description: "Overrides the Square API URL selected by environment, e.g. for a local stand-in server.",
+			},
+			"batch_window": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      client.DefaultBatchWindow.String(),
+				ValidateFunc: validateDuration,
+				Description:  "How long catalog operations are held so concurrent ones can be sent as one batch request; \"0s\" disables batching.",
+			},
+			"conflict_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      client.ConflictPolicyFail,
+				ValidateFunc: validation.StringInSlice([]string{client.ConflictPolicyFail, client.ConflictPolicyRetry}, false),
+				Description:  "What to do when an object was modified outside of Terraform since it was last read: 'fail' or 'retry' on top of the latest version.",
+			},
+			"environment": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc(squareEnvironmentEnvVar, client.EnvironmentSandbox),
+				ValidateFunc: validation.StringInSlice([]string{client.EnvironmentSandbox, client.EnvironmentProduction}, false),
+				Description:  "The Square environment to manage, either 'sandbox' or 'production'.",
+			},
+			"log_body_limit": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  client.DefaultLogBodyLimit,
+				ValidateFunc: func(v interface{}, k string) (wrns []string, errs []error) {
+					if val := v.(int); val == 0 || val < -1 {
+						errs = append(errs, fmt.Errorf("%s must be a positive number of bytes or -1, got %d", k, val))
+					}
+					return
+				},
+				Description: "The number of bytes of each Square API request and response body logged at TF_LOG=TRACE; -1 logs bodies in full.",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      client.DefaultMaxRetries,
+				ValidateFunc: validation.IntBetween(0, 10),
+				Description:  "The number of times a rate limited or transiently failing Square API request is retried.",
+			},
+			"max_retry_wait": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      client.DefaultMaxRetryWait.String(),
+				ValidateFunc: validateDuration,
+				Description:  "The longest time to wait between two attempts of a request, as a duration string (e.g. \"30s\").",
+			},
+			"prefetch_catalog": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Load the whole catalog on the first read and serve later reads from memory, speeding up refresh of large catalogs.",
+			},
+			"square_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc(squareVersionEnvVar, client.DefaultSquareVersion),
+				Description: "The value sent in the Square-Version header of every API request.",
+			},
+		},
 		ResourcesMap: map[string]*schema.Resource{
-			"square_catalog_category":       resourceSquareCatalogCategory(),
-			"square_catalog_discount":       resourceSquareCatalogDiscount(),
-			"square_catalog_item":           resourceSquareCatalogItem(),
-			"square_catalog_item_variation": resourceSquareCatalogItemVariation(),
-			"square_catalog_tax":            resourceSquareCatalogTax(),
+			"square_catalog_category":         resourceSquareCatalogCategory(),
+			"square_catalog_discount":         resourceSquareCatalogDiscount(),
+			"square_catalog_image":            resourceSquareCatalogImage(),
+			"square_catalog_item":             resourceSquareCatalogItem(),
+			"square_catalog_item_option":      resourceSquareCatalogItemOption(),
+			"square_catalog_item_variation":   resourceSquareCatalogItemVariation(),
+			"square_catalog_measurement_unit": resourceSquareCatalogMeasurementUnit(),
+			"square_catalog_modifier":         resourceSquareCatalogModifier(),
+			"square_catalog_modifier_list":    resourceSquareCatalogModifierList(),
+			"square_catalog_pricing_rule":     resourceSquareCatalogPricingRule(),
+			"square_catalog_product_set":      resourceSquareCatalogProductSet(),
+			"square_catalog_tax":              resourceSquareCatalogTax(),
+			"square_catalog_time_period":      resourceSquareCatalogTimePeriod(),
+			"square_inventory_count":          resourceSquareInventoryCount(),
 		},
 		ConfigureFunc: configureFn(),
 	}
@@ -27,11 +119,39 @@ func Provider() terraform.ResourceProvider {
 
 func configureFn() func(*schema.ResourceData) (interface{}, error) {
 	return func(d *schema.ResourceData) (interface{}, error) {
-		token, ok := os.LookupEnv(squarerAPIAccessTokenEnvVar)
-		if !ok {
-			return nil, fmt.Errorf("%s not set", squarerAPIAccessTokenEnvVar)
+		token := d.Get("access_token").(string)
+		if token == "" {
+			return nil, fmt.Errorf("access_token not set; configure it in the provider block or set %s", squareAPIAccessTokenEnvVar)
+		}
+
+		maxRetryWait, err := time.ParseDuration(d.Get("max_retry_wait").(string))
+		if err != nil {
+			return nil, err
+		}
+
+		batchWindow, err := time.ParseDuration(d.Get("batch_window").(string))
+		if err != nil {
+			return nil, err
+		}
+
+		c, err := client.NewClient(client.Config{
+			AccessToken:     token,
+			BaseURL:         d.Get("base_url").(string),
+			Environment:     d.Get("environment").(string),
+			SquareVersion:   d.Get("square_version").(string),
+			MaxRetries:      d.Get("max_retries").(int),
+			MaxRetryWait:    maxRetryWait,
+			BatchWindow:     batchWindow,
+			ConflictPolicy:  d.Get("conflict_policy").(string),
+			PrefetchCatalog: d.Get("prefetch_catalog").(bool),
+			LogBodyLimit:    d.Get("log_body_limit").(int),
+			Cassette:        os.Getenv(squareCassetteEnvVar),
+			CassetteMode:    os.Getenv(squareCassetteModeEnvVar),
+		})
+		if err != nil {
+			return nil, err
 		}
 
-		return client.NewClient(token), nil
+		return c, nil
 	}
 }
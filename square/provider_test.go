@@ -0,0 +1,204 @@
+package square
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/jefflinse/terraform-provider-square/square/client/squaretest"
+)
+
+// testAccLocationIDsEnvVar names the comma-separated IDs of two or more locations to scope catalog
+// objects to when running the acceptance tests against a real Square environment.
+const testAccLocationIDsEnvVar = "SQUARE_TEST_LOCATION_IDS"
+
+var (
+	testAccProvider  *schema.Provider
+	testAccProviders map[string]terraform.ResourceProvider
+
+	// testAccServer is the fake Square API the acceptance tests run against, unless
+	// SQUARE_BASE_URL or SQUARE_API_ACCESS_TOKEN point them at a real Square environment.
+	testAccServer *squaretest.Server
+)
+
+func init() {
+	testAccProvider = Provider().(*schema.Provider)
+	testAccProviders = map[string]terraform.ResourceProvider{
+		"square": testAccProvider,
+	}
+}
+
+func TestMain(m *testing.M) {
+	if os.Getenv(resource.TestEnvVar) != "" && os.Getenv(squareBaseURLEnvVar) == "" && os.Getenv(squareAPIAccessTokenEnvVar) == "" {
+		testAccServer = squaretest.NewServer()
+		testAccServer.AddLocation("LOCATION1", "Main Street")
+		testAccServer.AddLocation("LOCATION2", "Market Square")
+		os.Setenv(squareBaseURLEnvVar, testAccServer.URL)
+		os.Setenv(squareAPIAccessTokenEnvVar, "fake-access-token")
+	}
+
+	code := m.Run()
+
+	if testAccServer != nil {
+		testAccServer.Close()
+	}
+
+	os.Exit(code)
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestProvider_impl(t *testing.T) {
+	var _ terraform.ResourceProvider = Provider()
+}
+
+func testAccPreCheck(t *testing.T) {
+	if os.Getenv(squareAPIAccessTokenEnvVar) == "" {
+		t.Fatalf("%s must be set for acceptance tests", squareAPIAccessTokenEnvVar)
+	}
+}
+
+// Returns the IDs of two locations to scope catalog objects to, skipping the test when there are
+// not enough of them.
+func testAccLocationIDs(t *testing.T) (string, string) {
+	if testAccServer != nil {
+		return "LOCATION1", "LOCATION2"
+	}
+
+	ids := strings.Split(os.Getenv(testAccLocationIDsEnvVar), ",")
+	if len(ids) < 2 {
+		t.Skipf("%s must list two location IDs to test location presence", testAccLocationIDsEnvVar)
+	}
+
+	return ids[0], ids[1]
+}
+
+func TestAccProvider_conflictPolicyFail(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckFakeServer(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_category"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCatalogCategoryConfig("test", "Apparel"),
+				Check:  testAccCheckResourceID("square_catalog_category.test", &id, false),
+			},
+			{
+				PreConfig:   func() { testAccRenameBeforeNextUpsert(&id, "Outerwear") },
+				Config:      testAccCatalogCategoryConfig("test", "Clothing"),
+				ExpectError: regexp.MustCompile("was modified outside of Terraform after version \\d+ was read"),
+			},
+			{
+				// The object keeps the concurrent change until Terraform is applied again.
+				Config: testAccCatalogCategoryConfig("test", "Clothing"),
+				PreConfig: func() {
+					if obj, _ := testAccServer.Object(id); obj["category_data"].(map[string]interface{})["name"] != "Outerwear" {
+						t.Errorf("category is named %v after a rejected update, want the name given outside of Terraform", obj["category_data"])
+					}
+				},
+				Check: resource.TestCheckResourceAttr("square_catalog_category.test", "name", "Clothing"),
+			},
+		},
+	})
+}
+
+func TestAccProvider_conflictPolicyRetry(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckFakeServer(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_category"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfigConflictPolicy("retry") + testAccCatalogCategoryConfig("test", "Apparel"),
+				Check:  testAccCheckResourceID("square_catalog_category.test", &id, false),
+			},
+			{
+				PreConfig: func() { testAccRenameBeforeNextUpsert(&id, "Outerwear") },
+				Config:    testAccProviderConfigConflictPolicy("retry") + testAccCatalogCategoryConfig("test", "Clothing"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_category.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_category.test", "name", "Clothing"),
+					func(s *terraform.State) error {
+						obj, _ := testAccServer.Object(id)
+						if name := obj["category_data"].(map[string]interface{})["name"]; name != "Clothing" {
+							return fmt.Errorf("category is named %v, want the update reapplied on top of the concurrent change", name)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccProvider_conflictPolicyRetryVariation(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckFakeServer(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_item"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfigConflictPolicy("retry") + testAccSquareCatalogItemConfigVariations(
+					testAccSquareCatalogItemVariationBlock("Small", 300),
+				),
+				Check: testAccCheckBlockID("square_catalog_item.test", "variation", 0, &id),
+			},
+			{
+				// The variation's price is changed in the Square Dashboard before the update reaches it.
+				PreConfig: func() {
+					testAccServer.BeforeNext("POST /v2/catalog/object", func() {
+						testAccServer.UpdateObject(id, func(obj squaretest.Object) {
+							obj["item_variation_data"].(map[string]interface{})["price_money"] = map[string]interface{}{"amount": 350, "currency": "USD"}
+						})
+					})
+				},
+				Config: testAccProviderConfigConflictPolicy("retry") + testAccSquareCatalogItemConfigVariations(
+					testAccSquareCatalogItemVariationBlock("Small", 325),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBlockID("square_catalog_item.test", "variation", 0, &id),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "variation.0.price", "325"),
+				),
+			},
+		},
+	})
+}
+
+// Skips tests that need to modify the catalog behind Terraform's back, which only the fake server
+// allows.
+func testAccPreCheckFakeServer(t *testing.T) {
+	testAccPreCheck(t)
+	if testAccServer == nil {
+		t.Skip("modifying objects outside of Terraform needs the fake Square server")
+	}
+}
+
+// Renames the category with the specified ID as if it were renamed in the Square Dashboard after
+// Terraform read it and before its update reaches Square.
+func testAccRenameBeforeNextUpsert(id *string, name string) {
+	testAccServer.BeforeNext("POST /v2/catalog/object", func() {
+		testAccServer.UpdateObject(*id, func(obj squaretest.Object) {
+			obj["category_data"].(map[string]interface{})["name"] = name
+		})
+	})
+}
+
+func testAccProviderConfigConflictPolicy(policy string) string {
+	return fmt.Sprintf(`
+provider "square" {
+  conflict_policy = %q
+}
+`, policy)
+}
@@ -1,7 +1,9 @@
 package square
 
 import (
+	"errors"
 	"fmt"
+	"log"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	squaremodel "github.com/jefflinse/square-connect/models"
@@ -18,7 +20,7 @@ const (
 
 func resourceSquareCatalogCategory() *schema.Resource {
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
+		Schema: withIdempotencyKey(withLocationAttributes(map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -30,22 +32,28 @@ func resourceSquareCatalogCategory() *schema.Resource {
 					return
 				},
 			},
-		},
-		Create: resourceSquareCatalogCategoryCreate,
-		Read:   resourceSquareCatalogCategoryRead,
-		Update: resourceSquareCatalogCategoryUpdate,
-		Delete: resourceSquareCatalogCategoryDelete,
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		})),
+		Create:        resourceSquareCatalogCategoryCreate,
+		Read:          resourceSquareCatalogCategoryRead,
+		Update:        resourceSquareCatalogCategoryUpdate,
+		Delete:        resourceSquareCatalogCategoryDelete,
+		CustomizeDiff: validateCatalogObjectLocations,
+		Importer:      importCatalogObject(CategoryObjectType),
 	}
 }
 
 func resourceSquareCatalogCategoryCreate(d *schema.ResourceData, meta interface{}) error {
-	created, err := meta.(client.SquareAPI).UpsertCatalogObject(&squaremodel.CatalogObject{
-		ID:           newTempID(),
+	created, err := meta.(client.SquareAPI).UpsertCatalogObject(expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+		ID:           newCatalogObjectID(d),
 		Type:         strPtr(CategoryObjectType),
 		CategoryData: expandCatalogCategory(d),
-	})
+	}))
 	if err != nil {
-		return err
+		return attributeErrors(err, nil)
 	}
 
 	d.SetId(*created.ID)
@@ -55,36 +63,45 @@ func resourceSquareCatalogCategoryCreate(d *schema.ResourceData, meta interface{
 
 func resourceSquareCatalogCategoryRead(d *schema.ResourceData, meta interface{}) error {
 	obj, err := meta.(client.SquareAPI).RetrieveCatalogObject(d.Id())
-	if err != nil {
+	if errors.Is(err, client.ErrNotFound) {
+		log.Printf("[WARN] catalog category %s no longer exists, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	} else if err != nil {
 		return err
 	}
 
+	d.Set("version", obj.Version)
+	flattenCatalogObjectLocations(obj, d)
+
 	return flattenCatalogCategory(obj.CategoryData, d)
 }
 
 func resourceSquareCatalogCategoryUpdate(d *schema.ResourceData, meta interface{}) error {
-	if d.HasChange("name") {
-		client := meta.(client.SquareAPI)
-		obj, err := client.RetrieveCatalogObject(d.Id())
-		if err != nil {
-			return err
-		}
+	if d.HasChange("absent_at_location_ids") ||
+		d.HasChange("name") ||
+		d.HasChange("present_at_all_locations") ||
+		d.HasChange("present_at_location_ids") {
 
-		if _, err := client.UpsertCatalogObject(&squaremodel.CatalogObject{
-			ID:           strPtr(*obj.ID),
+		if _, err := meta.(client.SquareAPI).UpsertCatalogObject(expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+			ID:           strPtr(d.Id()),
 			Type:         strPtr(CategoryObjectType),
-			Version:      obj.Version,
+			Version:      int64(d.Get("version").(int)),
 			CategoryData: expandCatalogCategory(d),
-		}); err != nil {
-			return err
+		})); err != nil {
+			return attributeErrors(err, nil)
 		}
 	}
 
-	return nil
+	return resourceSquareCatalogCategoryRead(d, meta)
 }
 
 func resourceSquareCatalogCategoryDelete(d *schema.ResourceData, meta interface{}) error {
 	_, err := meta.(client.SquareAPI).DeleteCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		return nil
+	}
+
 	return err
 }
 
@@ -0,0 +1,111 @@
+package square
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/jefflinse/terraform-provider-square/square/client"
+	"github.com/jefflinse/terraform-provider-square/square/client/squaretest"
+)
+
+func TestAccSquareCatalogCategory_rename(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_category"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCatalogCategoryConfig("test", "Apparel"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_category.test"),
+					testAccCheckResourceID("square_catalog_category.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_category.test", "name", "Apparel"),
+					resource.TestCheckResourceAttrSet("square_catalog_category.test", "version"),
+				),
+			},
+			{
+				Config: testAccCatalogCategoryConfig("test", "Clothing"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_category.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_category.test", "name", "Clothing"),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_category.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogCategory_importWrongType(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_tax"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogTaxConfig("Sales Tax", "TAX_SUBTOTAL_PHASE", "ADDITIVE", "8.5", false, false),
+			},
+			{
+				Config:       testAccSquareCatalogTaxConfig("Sales Tax", "TAX_SUBTOTAL_PHASE", "ADDITIVE", "8.5", false, false),
+				ResourceName: "square_catalog_category.imported",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources["square_catalog_tax.test"].Primary.ID, nil
+				},
+				ExpectError: regexp.MustCompile("it is a TAX, not a CATEGORY"),
+			},
+		},
+	})
+}
+
+// Identical resources, in one configuration or in separate runs of Terraform, create categories of
+// their own, while a resource retrying its create gets the category it created before.
+func TestResourceSquareCatalogCategory_identicalResources(t *testing.T) {
+	server := squaretest.NewServer()
+	defer server.Close()
+
+	c, err := client.NewClient(client.Config{AccessToken: "token", BaseURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := []string{}
+	for i := 0; i < 2; i++ {
+		d := schema.TestResourceDataRaw(t, resourceSquareCatalogCategory().Schema, map[string]interface{}{
+			"name": "Apparel",
+		})
+		if err := resourceSquareCatalogCategoryCreate(d, c); err != nil {
+			t.Fatal(err)
+		}
+
+		id := d.Id()
+		if err := resourceSquareCatalogCategoryCreate(d, c); err != nil {
+			t.Fatal(err)
+		}
+		if d.Id() != id {
+			t.Errorf("retried create made category %s, want %s", d.Id(), id)
+		}
+
+		ids = append(ids, id)
+	}
+
+	if ids[0] == ids[1] {
+		t.Errorf("resources created the same category %s, want two categories", ids[0])
+	}
+
+	categories, err := c.ListCatalog(CategoryObjectType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(categories) != 2 {
+		t.Errorf("catalog has %d categories, want 2", len(categories))
+	}
+}
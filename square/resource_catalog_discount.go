@@ -1,7 +1,9 @@
 package square
 
 import (
+	"errors"
 	"fmt"
+	"log"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	squaremodel "github.com/jefflinse/square-connect/models"
@@ -26,9 +28,16 @@ const (
 	DiscountTypeVariablePercentage = "VARIABLE_PERCENTAGE"
 )
 
+// Maps Square discount fields to the attributes they are configured by.
+var catalogDiscountFields = map[string]string{
+	"amount_money.amount":   "amount",
+	"amount_money.currency": "currency",
+	"discount_type":         "type",
+}
+
 func resourceSquareCatalogDiscount() *schema.Resource {
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
+		Schema: withIdempotencyKey(withLocationAttributes(map[string]*schema.Schema{
 			"amount": {
 				Type:          schema.TypeInt,
 				Optional:      true,
@@ -71,22 +80,28 @@ func resourceSquareCatalogDiscount() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
-		},
-		Create: resourceSquareCatalogDiscountCreate,
-		Read:   resourceSquareCatalogDiscountRead,
-		Update: resourceSquareCatalogDiscountUpdate,
-		Delete: resourceSquareCatalogDiscountDelete,
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		})),
+		Create:        resourceSquareCatalogDiscountCreate,
+		Read:          resourceSquareCatalogDiscountRead,
+		Update:        resourceSquareCatalogDiscountUpdate,
+		Delete:        resourceSquareCatalogDiscountDelete,
+		CustomizeDiff: validateCatalogObjectLocations,
+		Importer:      importCatalogObject(DiscountObjectType),
 	}
 }
 
 func resourceSquareCatalogDiscountCreate(d *schema.ResourceData, meta interface{}) error {
-	created, err := meta.(client.SquareAPI).UpsertCatalogObject(&squaremodel.CatalogObject{
-		ID:           newTempID(),
+	created, err := meta.(client.SquareAPI).UpsertCatalogObject(expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+		ID:           newCatalogObjectID(d),
 		Type:         strPtr(DiscountObjectType),
 		DiscountData: expandCatalogDiscount(d),
-	})
+	}))
 	if err != nil {
-		return err
+		return attributeErrors(err, catalogDiscountFields)
 	}
 
 	d.SetId(*created.ID)
@@ -96,44 +111,52 @@ func resourceSquareCatalogDiscountCreate(d *schema.ResourceData, meta interface{
 
 func resourceSquareCatalogDiscountRead(d *schema.ResourceData, meta interface{}) error {
 	obj, err := meta.(client.SquareAPI).RetrieveCatalogObject(d.Id())
-	if err != nil {
+	if errors.Is(err, client.ErrNotFound) {
+		log.Printf("[WARN] catalog discount %s no longer exists, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	} else if err != nil {
 		return err
 	}
 
+	d.Set("version", obj.Version)
+	flattenCatalogObjectLocations(obj, d)
+
 	return flattenCatalogDiscount(obj.DiscountData, d)
 }
 
 func resourceSquareCatalogDiscountUpdate(d *schema.ResourceData, meta interface{}) error {
-	if d.HasChange("amount") ||
+	if d.HasChange("absent_at_location_ids") ||
+		d.HasChange("amount") ||
 		d.HasChange("currency") ||
 		d.HasChange("label_color") ||
 		d.HasChange("modify_tax_basis") ||
 		d.HasChange("name") ||
 		d.HasChange("percentage") ||
 		d.HasChange("pin_required") ||
+		d.HasChange("present_at_all_locations") ||
+		d.HasChange("present_at_location_ids") ||
 		d.HasChange("type") {
 
-		client := meta.(client.SquareAPI)
-		obj, err := client.RetrieveCatalogObject(d.Id())
-		if err != nil {
-			return err
-		}
-
-		if _, err := client.UpsertCatalogObject(&squaremodel.CatalogObject{
-			ID:           strPtr(*obj.ID),
+		if _, err := meta.(client.SquareAPI).UpsertCatalogObject(expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+			ID:           strPtr(d.Id()),
 			Type:         strPtr(DiscountObjectType),
-			Version:      obj.Version,
+			Version:      int64(d.Get("version").(int)),
 			DiscountData: expandCatalogDiscount(d),
-		}); err != nil {
-			return err
+		})); err != nil {
+			return attributeErrors(err, catalogDiscountFields)
 		}
 	}
 
-	return nil
+	return resourceSquareCatalogDiscountRead(d, meta)
 }
 
 func resourceSquareCatalogDiscountDelete(d *schema.ResourceData, meta interface{}) error {
 	_, err := meta.(client.SquareAPI).DeleteCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		return nil
+	}
+
 	return err
 }
 
@@ -169,10 +192,15 @@ func flattenCatalogDiscount(discount *squaremodel.CatalogDiscount, d *schema.Res
 	d.Set("pin_required", discount.PinRequired)
 	d.Set("type", discount.DiscountType)
 
+	d.Set("amount", 0)
+	d.Set("currency", "")
+
 	switch discount.DiscountType {
 	case DiscountTypeFixedAmount:
-		d.Set("amount", discount.AmountMoney.Amount)
-		d.Set("currency", discount.AmountMoney.Currency)
+		if discount.AmountMoney != nil {
+			d.Set("amount", discount.AmountMoney.Amount)
+			d.Set("currency", discount.AmountMoney.Currency)
+		}
 	case DiscountTypeFixedPercentage:
 		d.Set("percentage", discount.Percentage)
 	case DiscountTypeVariablePercentage:
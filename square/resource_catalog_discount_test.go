@@ -0,0 +1,108 @@
+package square
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSquareCatalogDiscount_fixedAmount(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_discount"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogDiscountConfigAmount("Fifty Off", 50, "USD", "FF0000", "MODIFY_TAX_BASIS", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_discount.test"),
+					testAccCheckResourceID("square_catalog_discount.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_discount.test", "name", "Fifty Off"),
+					resource.TestCheckResourceAttr("square_catalog_discount.test", "type", "FIXED_AMOUNT"),
+					resource.TestCheckResourceAttr("square_catalog_discount.test", "amount", "50"),
+					resource.TestCheckResourceAttr("square_catalog_discount.test", "currency", "USD"),
+					resource.TestCheckResourceAttr("square_catalog_discount.test", "label_color", "FF0000"),
+					resource.TestCheckResourceAttr("square_catalog_discount.test", "modify_tax_basis", "MODIFY_TAX_BASIS"),
+					resource.TestCheckResourceAttr("square_catalog_discount.test", "pin_required", "false"),
+					resource.TestCheckResourceAttrSet("square_catalog_discount.test", "version"),
+				),
+			},
+			{
+				Config: testAccSquareCatalogDiscountConfigAmount("Dollar Off", 100, "CAD", "00FF00", "DO_NOT_MODIFY_TAX_BASIS", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_discount.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_discount.test", "name", "Dollar Off"),
+					resource.TestCheckResourceAttr("square_catalog_discount.test", "amount", "100"),
+					resource.TestCheckResourceAttr("square_catalog_discount.test", "currency", "CAD"),
+					resource.TestCheckResourceAttr("square_catalog_discount.test", "label_color", "00FF00"),
+					resource.TestCheckResourceAttr("square_catalog_discount.test", "modify_tax_basis", "DO_NOT_MODIFY_TAX_BASIS"),
+					resource.TestCheckResourceAttr("square_catalog_discount.test", "pin_required", "true"),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_discount.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogDiscount_changeType(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_discount"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogDiscountConfigAmount("Fifty Off", 50, "USD", "FF0000", "MODIFY_TAX_BASIS", false),
+				Check:  testAccCheckResourceID("square_catalog_discount.test", &id, false),
+			},
+			{
+				Config: testAccSquareCatalogDiscountConfigPercentage("Ten Percent", "10.0"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_discount.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_discount.test", "type", "FIXED_PERCENTAGE"),
+					resource.TestCheckResourceAttr("square_catalog_discount.test", "percentage", "10.0"),
+					resource.TestCheckResourceAttr("square_catalog_discount.test", "amount", "0"),
+					resource.TestCheckResourceAttr("square_catalog_discount.test", "currency", ""),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_discount.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+		},
+	})
+}
+
+func testAccSquareCatalogDiscountConfigAmount(name string, amount int, currency, color, taxBasis string, pin bool) string {
+	return fmt.Sprintf(`
+resource "square_catalog_discount" "test" {
+  name             = %q
+  type             = "FIXED_AMOUNT"
+  amount           = %d
+  currency         = %q
+  label_color      = %q
+  modify_tax_basis = %q
+  pin_required     = %t
+}
+`, name, amount, currency, color, taxBasis, pin)
+}
+
+func testAccSquareCatalogDiscountConfigPercentage(name, percentage string) string {
+	return fmt.Sprintf(`
+resource "square_catalog_discount" "test" {
+  name             = %q
+  type             = "FIXED_PERCENTAGE"
+  percentage       = %q
+  modify_tax_basis = "MODIFY_TAX_BASIS"
+}
+`, name, percentage)
+}
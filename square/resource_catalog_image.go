@@ -0,0 +1,227 @@
+package square
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	squaremodel "github.com/jefflinse/square-connect/models"
+	"github.com/jefflinse/terraform-provider-square/square/client"
+)
+
+const (
+	// CatalogImageNameMaxLength is the maximum length for an image's name.
+	CatalogImageNameMaxLength = 255
+
+	// ImageObjectType is the Square type for a catalog object describing an image.
+	ImageObjectType = "IMAGE"
+)
+
+func resourceSquareCatalogImage() *schema.Resource {
+	return &schema.Resource{
+		Schema: withIdempotencyKey(withLocationAttributes(map[string]*schema.Schema{
+			"caption": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"content_sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(v interface{}, k string) (wrns []string, errs []error) {
+					val := v.(string)
+					if len(val) > CatalogImageNameMaxLength {
+						errs = append(errs, fmt.Errorf("image name '%s' exceeds max length of %d", val, CatalogImageNameMaxLength))
+					}
+					return
+				},
+			},
+			"object_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"source": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		})),
+		Create:        resourceSquareCatalogImageCreate,
+		Read:          resourceSquareCatalogImageRead,
+		Update:        resourceSquareCatalogImageUpdate,
+		Delete:        resourceSquareCatalogImageDelete,
+		CustomizeDiff: resourceSquareCatalogImageCustomizeDiff,
+		Importer:      importCatalogObject(ImageObjectType),
+	}
+}
+
+// Square images cannot be replaced in place, so a change to the content of the source file
+// replaces the image. Moving or renaming the file without changing it does not, and neither does
+// the first plan after an import, which only records the file the image is now managed from.
+func resourceSquareCatalogImageCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateCatalogObjectLocations(d, meta); err != nil {
+		return err
+	}
+
+	if !d.NewValueKnown("source") {
+		return d.SetNewComputed("content_sha256")
+	}
+
+	sum, err := fileSHA256(d.Get("source").(string))
+	if err != nil {
+		return err
+	}
+
+	old, _ := d.GetChange("content_sha256")
+	if sum == old.(string) {
+		return nil
+	}
+
+	if err := d.SetNew("content_sha256", sum); err != nil {
+		return err
+	}
+
+	if d.Id() != "" && old.(string) != "" {
+		return d.ForceNew("content_sha256")
+	}
+
+	return nil
+}
+
+func resourceSquareCatalogImageCreate(d *schema.ResourceData, meta interface{}) error {
+	source := d.Get("source").(string)
+	file, err := ioutil.ReadFile(source)
+	if err != nil {
+		return err
+	}
+
+	created, err := meta.(client.SquareAPI).CreateCatalogImage(
+		d.Get("object_id").(string),
+		expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+			ID:        newCatalogObjectID(d),
+			Type:      strPtr(ImageObjectType),
+			ImageData: expandCatalogImage(d),
+		}),
+		filepath.Base(source),
+		bytes.NewReader(file),
+	)
+	if err != nil {
+		return attributeErrors(err, nil)
+	}
+
+	sum := sha256.Sum256(file)
+	d.SetId(*created.ID)
+	d.Set("content_sha256", hex.EncodeToString(sum[:]))
+
+	return resourceSquareCatalogImageRead(d, meta)
+}
+
+func resourceSquareCatalogImageRead(d *schema.ResourceData, meta interface{}) error {
+	api := meta.(client.SquareAPI)
+	obj, err := api.RetrieveCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		log.Printf("[WARN] catalog image %s no longer exists, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	d.Set("version", obj.Version)
+	flattenCatalogObjectLocations(obj, d)
+
+	// Square does not record which object an image is attached to, so check the other way around.
+	if objectID := d.Get("object_id").(string); objectID != "" {
+		attached, err := api.RetrieveCatalogObject(objectID)
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			return err
+		}
+
+		if attached == nil || attached.ImageID != d.Id() {
+			log.Printf("[WARN] catalog image %s is no longer attached to catalog object %s", d.Id(), objectID)
+			d.Set("object_id", "")
+		}
+	}
+
+	return flattenCatalogImage(obj.ImageData, d)
+}
+
+func resourceSquareCatalogImageUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("absent_at_location_ids") ||
+		d.HasChange("caption") ||
+		d.HasChange("name") ||
+		d.HasChange("present_at_all_locations") ||
+		d.HasChange("present_at_location_ids") {
+
+		if _, err := meta.(client.SquareAPI).UpsertCatalogObject(expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+			ID:        strPtr(d.Id()),
+			Type:      strPtr(ImageObjectType),
+			Version:   int64(d.Get("version").(int)),
+			ImageData: expandCatalogImage(d),
+		})); err != nil {
+			return attributeErrors(err, nil)
+		}
+	}
+
+	return resourceSquareCatalogImageRead(d, meta)
+}
+
+func resourceSquareCatalogImageDelete(d *schema.ResourceData, meta interface{}) error {
+	_, err := meta.(client.SquareAPI).DeleteCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+func expandCatalogImage(d *schema.ResourceData) *squaremodel.CatalogImage {
+	return &squaremodel.CatalogImage{
+		Caption: d.Get("caption").(string),
+		Name:    d.Get("name").(string),
+		URL:     d.Get("url").(string),
+	}
+}
+
+func flattenCatalogImage(image *squaremodel.CatalogImage, d *schema.ResourceData) error {
+	d.Set("caption", image.Caption)
+	d.Set("name", image.Name)
+	d.Set("url", image.URL)
+
+	return nil
+}
+
+// Returns the hex-encoded SHA-256 digest of a file's content.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
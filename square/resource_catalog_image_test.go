@@ -0,0 +1,145 @@
+package square
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/jefflinse/terraform-provider-square/square/client"
+)
+
+// 1x1 pixel images in two formats, so that changing the source file changes its content.
+var (
+	testAccPNG, _ = base64.StdEncoding.DecodeString("iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNk+M9QDwADhgGAWjR9awAAAABJRU5ErkJggg==")
+	testAccGIF, _ = base64.StdEncoding.DecodeString("R0lGODlhAQABAIAAAAAAAP///yH5BAEAAAAALAAAAAABAAEAAAIBRAA7")
+)
+
+func TestAccSquareCatalogImage_update(t *testing.T) {
+	source := testAccImageSource(t, testAccPNG)
+
+	var imageID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_image"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogImageConfig(source, "Front", "T-shirt front"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_image.test"),
+					resource.TestCheckResourceAttr("square_catalog_image.test", "caption", "Front"),
+					resource.TestCheckResourceAttr("square_catalog_image.test", "name", "T-shirt front"),
+					resource.TestCheckResourceAttr("square_catalog_image.test", "content_sha256", "6b7fa434f92a8b80aab02d9bf1a12e49ffcae424e4013a1c4f68b67e3d2bbcd0"),
+					resource.TestCheckResourceAttrPair("square_catalog_image.test", "object_id", "square_catalog_item.test", "id"),
+					resource.TestCheckResourceAttrSet("square_catalog_image.test", "url"),
+					resource.TestCheckResourceAttrSet("square_catalog_image.test", "version"),
+					testAccCheckCatalogImageAttached("square_catalog_image.test", "square_catalog_item.test", testAccPNG),
+					testAccCheckResourceID("square_catalog_image.test", &imageID, false),
+				),
+			},
+			{
+				Config: testAccSquareCatalogImageConfig(source, "Back", "T-shirt back"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_catalog_image.test", "caption", "Back"),
+					resource.TestCheckResourceAttr("square_catalog_image.test", "name", "T-shirt back"),
+					resource.TestCheckResourceAttrPair("square_catalog_item.test", "image_id", "square_catalog_image.test", "id"),
+					testAccCheckResourceID("square_catalog_image.test", &imageID, false),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_image.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"content_sha256", "idempotency_key", "object_id", "source"},
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogImage_newContent(t *testing.T) {
+	source := testAccImageSource(t, testAccPNG)
+
+	var imageID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_image"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogImageConfig(source, "Front", "T-shirt front"),
+				Check:  testAccCheckResourceID("square_catalog_image.test", &imageID, false),
+			},
+			{
+				// Square cannot replace an image's file, so a new image is uploaded.
+				PreConfig: func() {
+					if err := ioutil.WriteFile(source, testAccGIF, 0644); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccSquareCatalogImageConfig(source, "Front", "T-shirt front"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogImageAttached("square_catalog_image.test", "square_catalog_item.test", testAccGIF),
+					testAccCheckResourceID("square_catalog_image.test", &imageID, true),
+				),
+			},
+		},
+	})
+}
+
+// Writes content to a temporary file to upload as an image, returning its path.
+func testAccImageSource(t *testing.T, content []byte) string {
+	source := filepath.Join(t.TempDir(), "tshirt.png")
+	if err := ioutil.WriteFile(source, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return source
+}
+
+// Verifies that the image is attached to the object and, against the fake, that its file was uploaded.
+func testAccCheckCatalogImageAttached(imageName, objectName string, content []byte) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		image, ok := s.RootModule().Resources[imageName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", imageName)
+		}
+
+		object, ok := s.RootModule().Resources[objectName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", objectName)
+		}
+
+		obj, err := testAccProvider.Meta().(client.SquareAPI).RetrieveCatalogObject(object.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if obj.ImageID != image.Primary.ID {
+			return fmt.Errorf("%s has image %q, expected %s", objectName, obj.ImageID, image.Primary.ID)
+		}
+
+		if testAccServer != nil {
+			if uploaded, _ := testAccServer.Image(image.Primary.ID); !bytes.Equal(uploaded, content) {
+				return fmt.Errorf("image %s has the wrong content", image.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccSquareCatalogImageConfig(source, caption, name string) string {
+	return testAccCatalogItemConfig("test", "T-shirt") + fmt.Sprintf(`
+resource "square_catalog_image" "test" {
+  source    = %q
+  object_id = square_catalog_item.test.id
+  caption   = %q
+  name      = %q
+}
+`, source, caption, name)
+}
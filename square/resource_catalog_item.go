@@ -1,9 +1,14 @@
 package square
 
 import (
+	"errors"
 	"fmt"
+	"log"
+	"math"
+	"sort"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	squaremodel "github.com/jefflinse/square-connect/models"
 	"github.com/jefflinse/terraform-provider-square/square/client"
 )
@@ -14,11 +19,45 @@ const (
 
 	// ItemObjectType is the Square type for a catalog object describing an item.
 	ItemObjectType = "ITEM"
+
+	// ProductTypeAppointmentsService designates an item that is a service booked through Square Appointments.
+	ProductTypeAppointmentsService = "APPOINTMENTS_SERVICE"
+
+	// ProductTypeFoodAndBev designates an item that is food or a drink.
+	ProductTypeFoodAndBev = "FOOD_AND_BEV"
+
+	// ProductTypeRegular designates an item that is an ordinary product.
+	ProductTypeRegular = "REGULAR"
 )
 
+// catalogItemProductTypes are the product types an item can be created with.
+var catalogItemProductTypes = []string{ProductTypeAppointmentsService, "DIGITAL", "DONATION", "EVENT", ProductTypeFoodAndBev, ProductTypeRegular}
+
+// catalogItemEcomVisibilities are the ways an item can be shown on a Square Online site.
+var catalogItemEcomVisibilities = []string{"HIDDEN", "UNAVAILABLE", "UNINDEXED", "VISIBLE"}
+
+// catalogItemDietaryPreferences are the standard dietary preferences a food or drink can meet.
+var catalogItemDietaryPreferences = []string{"DAIRY_FREE", "GLUTEN_FREE", "HALAL", "KOSHER", "NUT_FREE", "VEGAN", "VEGETARIAN"}
+
+// catalogItemIngredients are the standard ingredients, mostly allergens, a food or drink can contain.
+var catalogItemIngredients = []string{
+	"CELERY", "CRUSTACEANS", "EGGS", "FISH", "GLUTEN", "LUPIN", "MILK",
+	"MOLLUSCS", "MUSTARD", "PEANUTS", "SESAME", "SOY", "SULPHITES", "TREE_NUTS",
+}
+
+// Maps Square item fields to the attributes they are configured by. Errors in a variation are
+// reported for its block and translated like those of a standalone variation.
+var catalogItemFields = func() map[string]string {
+	fields := map[string]string{"variations": "variation"}
+	for field, attr := range catalogItemVariationFields {
+		fields[field] = attr
+	}
+	return fields
+}()
+
 func resourceSquareCatalogItem() *schema.Resource {
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
+		Schema: withIdempotencyKey(withLocationAttributes(map[string]*schema.Schema{
 			"abbreviation": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -46,23 +85,175 @@ func resourceSquareCatalogItem() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"channels": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"description": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"description_html"},
+			},
+			"description_html": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"description"},
+			},
+			"ecom_visibility": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(catalogItemEcomVisibilities, false),
+			},
+			"food_and_beverage_details": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"calorie_count": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(0, math.MaxInt32),
+						},
+						"custom_dietary_preferences": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"custom_ingredients": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"dietary_preferences": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(catalogItemDietaryPreferences, false),
+							},
+						},
+						"ingredients": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(catalogItemIngredients, false),
+							},
+						},
+					},
+				},
+			},
+			"image_id": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
+			},
+			"image_ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"is_taxable": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"item_options": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
 			},
 			"label_color": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"modifier_list_info": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"max_selected_modifiers": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      -1,
+							ValidateFunc: validation.IntBetween(-1, math.MaxInt32),
+						},
+						"min_selected_modifiers": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      -1,
+							ValidateFunc: validation.IntBetween(-1, math.MaxInt32),
+						},
+						"modifier_list_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"modifier_overrides": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"modifier_id": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"on_by_default": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"product_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      ProductTypeRegular,
+				ValidateFunc: validation.StringInSlice(catalogItemProductTypes, false),
+			},
+			"reporting_category_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 			"skip_modifier_screen": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  false,
 			},
+			"sort_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
 			"tax_ids": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -70,22 +261,85 @@ func resourceSquareCatalogItem() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
-		},
-		Create: resourceSquareCatalogItemCreate,
-		Read:   resourceSquareCatalogItemRead,
-		Update: resourceSquareCatalogItemUpdate,
-		Delete: resourceSquareCatalogItemDelete,
+			"variation": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: catalogItemVariationBlockSchema(),
+				},
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		})),
+		Create:        resourceSquareCatalogItemCreate,
+		Read:          resourceSquareCatalogItemRead,
+		Update:        resourceSquareCatalogItemUpdate,
+		Delete:        resourceSquareCatalogItemDelete,
+		CustomizeDiff: resourceSquareCatalogItemCustomizeDiff,
+		Importer:      importCatalogObject(ItemObjectType),
+	}
+}
+
+// Returns the attributes of a variation block, which are those of an item variation resource
+// except for the item it belongs to and the locations it is present at, both of which it takes
+// from the item.
+func catalogItemVariationBlockSchema() map[string]*schema.Schema {
+	s := catalogItemVariationSchema()
+	s["id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	s["image_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+	}
+	s["version"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Computed: true,
+	}
+
+	return s
+}
+
+func resourceSquareCatalogItemCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateCatalogObjectLocations(d, meta); err != nil {
+		return err
+	}
+
+	if productType := d.Get("product_type").(string); productType != ProductTypeFoodAndBev && d.Get("food_and_beverage_details.#").(int) > 0 {
+		return fmt.Errorf("food_and_beverage_details can only be set with product_type %s, not %s", ProductTypeFoodAndBev, productType)
+	}
+
+	if !d.NewValueKnown("variation.#") {
+		return nil
+	}
+
+	productType := func() (string, error) {
+		return d.Get("product_type").(string), nil
+	}
+	for i := 0; i < d.Get("variation.#").(int); i++ {
+		if err := validateCatalogItemVariation(d, fmt.Sprintf("variation.%d.", i), productType, meta); err != nil {
+			return fmt.Errorf("variation %d: %w", i, err)
+		}
 	}
+
+	return nil
 }
 
 func resourceSquareCatalogItemCreate(d *schema.ResourceData, meta interface{}) error {
-	created, err := meta.(client.SquareAPI).UpsertCatalogObject(&squaremodel.CatalogObject{
-		ID:       newTempID(),
-		Type:     strPtr(ItemObjectType),
-		ItemData: expandCatalogItem(d),
+	obj := expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+		ID:      newCatalogObjectID(d),
+		Type:    strPtr(ItemObjectType),
+		ImageID: d.Get("image_id").(string),
 	})
+	expandCatalogItem(d, obj, nil)
+
+	created, err := meta.(client.SquareAPI).UpsertCatalogObject(obj)
 	if err != nil {
-		return err
+		return attributeErrors(err, catalogItemFields)
 	}
 
 	d.SetId(*created.ID)
@@ -95,50 +349,90 @@ func resourceSquareCatalogItemCreate(d *schema.ResourceData, meta interface{}) e
 
 func resourceSquareCatalogItemRead(d *schema.ResourceData, meta interface{}) error {
 	obj, err := meta.(client.SquareAPI).RetrieveCatalogObject(d.Id())
-	if err != nil {
+	if errors.Is(err, client.ErrNotFound) {
+		log.Printf("[WARN] catalog item %s no longer exists, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	} else if err != nil {
 		return err
 	}
 
-	return flattenCatalogItem(obj.ItemData, d)
+	d.Set("image_id", obj.ImageID)
+	d.Set("version", obj.Version)
+	flattenCatalogObjectLocations(obj, d)
+
+	return flattenCatalogItem(obj, d)
 }
 
 func resourceSquareCatalogItemUpdate(d *schema.ResourceData, meta interface{}) error {
 	if d.HasChange("abbreviation") ||
+		d.HasChange("absent_at_location_ids") ||
 		d.HasChange("available_electronically") ||
 		d.HasChange("available_for_pickup") ||
 		d.HasChange("available_online") ||
 		d.HasChange("category_id") ||
+		d.HasChange("channels") ||
 		d.HasChange("description") ||
+		d.HasChange("description_html") ||
+		d.HasChange("ecom_visibility") ||
+		d.HasChange("food_and_beverage_details") ||
+		d.HasChange("image_id") ||
+		d.HasChange("image_ids") ||
+		d.HasChange("is_taxable") ||
+		d.HasChange("item_options") ||
 		d.HasChange("label_color") ||
+		d.HasChange("modifier_list_info") ||
 		d.HasChange("name") ||
+		d.HasChange("present_at_all_locations") ||
+		d.HasChange("present_at_location_ids") ||
+		d.HasChange("reporting_category_id") ||
 		d.HasChange("skip_modifier_screen") ||
-		d.HasChange("tax_ids") {
+		d.HasChange("sort_name") ||
+		d.HasChange("tax_ids") ||
+		d.HasChange("variation") {
 
-		client := meta.(client.SquareAPI)
-		obj, err := client.RetrieveCatalogObject(d.Id())
-		if err != nil {
-			return err
+		// Variation blocks are matched to the variations the item has in Square.
+		var existing []*client.CatalogObject
+		if d.Get("variation.#").(int) > 0 {
+			item, err := meta.(client.SquareAPI).RetrieveCatalogObject(d.Id())
+			if err != nil {
+				return err
+			}
+			existing = item.Children()
+		}
+
+		obj := expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+			ID:      strPtr(d.Id()),
+			Type:    strPtr(ItemObjectType),
+			ImageID: d.Get("image_id").(string),
+			Version: int64(d.Get("version").(int)),
+		})
+		expandCatalogItem(d, obj, existing)
+
+		if _, err := meta.(client.SquareAPI).UpsertCatalogObject(obj); err != nil {
+			return attributeErrors(err, catalogItemFields)
 		}
 
-		if _, err := client.UpsertCatalogObject(&squaremodel.CatalogObject{
-			ID:       strPtr(*obj.ID),
-			Type:     strPtr(ItemObjectType),
-			Version:  obj.Version,
-			ItemData: expandCatalogItem(d),
-		}); err != nil {
+		if err := deleteRemovedCatalogItemVariations(d, meta); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return resourceSquareCatalogItemRead(d, meta)
 }
 
 func resourceSquareCatalogItemDelete(d *schema.ResourceData, meta interface{}) error {
 	_, err := meta.(client.SquareAPI).DeleteCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		return nil
+	}
+
 	return err
 }
 
-func expandCatalogItem(d *schema.ResourceData) *squaremodel.CatalogItem {
+// Sets the item data of a catalog object, along with its variations, from the resource's attributes.
+// Variation blocks update the item's existing variations they match.
+func expandCatalogItem(d *schema.ResourceData, obj *client.CatalogObject, existing []*client.CatalogObject) {
 	item := &squaremodel.CatalogItem{
 		Abbreviation:            d.Get("abbreviation").(string),
 		AvailableElectronically: d.Get("available_electronically").(bool),
@@ -148,29 +442,365 @@ func expandCatalogItem(d *schema.ResourceData) *squaremodel.CatalogItem {
 		Description:             d.Get("description").(string),
 		LabelColor:              d.Get("label_color").(string),
 		Name:                    d.Get("name").(string),
+		ProductType:             d.Get("product_type").(string),
 		SkipModifierScreen:      d.Get("skip_modifier_screen").(bool),
 	}
 
+	// Fields the models predate are set on the object. Those Square fills in when they are not
+	// configured are only sent once known, so that they are not cleared.
+	obj.SetField("item_data.is_taxable", d.Get("is_taxable").(bool))
+	if channels := d.Get("channels").(*schema.Set); channels.Len() > 0 {
+		obj.SetField("item_data.channels", sortedStrings(channels))
+	}
+	if html := d.Get("description_html").(string); html != "" {
+		obj.SetField("item_data.description_html", html)
+	}
+	if visibility := d.Get("ecom_visibility").(string); visibility != "" {
+		obj.SetField("item_data.ecom_visibility", visibility)
+	}
+	if details := d.Get("food_and_beverage_details").([]interface{}); len(details) > 0 && details[0] != nil {
+		obj.SetField("item_data.food_and_beverage_details", expandCatalogItemFoodAndBeverageDetails(details[0].(map[string]interface{})))
+	}
+	if imageIDs := d.Get("image_ids").([]interface{}); len(imageIDs) > 0 {
+		obj.SetField("item_data.image_ids", imageIDs)
+	}
+	if categoryID := d.Get("reporting_category_id").(string); categoryID != "" {
+		obj.SetField("item_data.reporting_category", map[string]interface{}{"id": categoryID})
+	}
+	if sortName := d.Get("sort_name").(string); sortName != "" {
+		obj.SetField("item_data.sort_name", sortName)
+	}
+
 	taxIDs := d.Get("tax_ids").(*schema.Set).List()
 	item.TaxIds = []string{}
 	for _, tid := range taxIDs {
 		item.TaxIds = append(item.TaxIds, tid.(string))
 	}
 
-	return item
+	item.ItemOptions = []*squaremodel.CatalogItemOptionForItem{}
+	for _, id := range d.Get("item_options").([]interface{}) {
+		item.ItemOptions = append(item.ItemOptions, &squaremodel.CatalogItemOptionForItem{
+			ItemOptionID: id.(string),
+		})
+	}
+
+	item.ModifierListInfo = []*squaremodel.CatalogItemModifierListInfo{}
+	for _, raw := range d.Get("modifier_list_info").([]interface{}) {
+		info := raw.(map[string]interface{})
+		modifierListInfo := &squaremodel.CatalogItemModifierListInfo{
+			Enabled:              info["enabled"].(bool),
+			MaxSelectedModifiers: int64(info["max_selected_modifiers"].(int)),
+			MinSelectedModifiers: int64(info["min_selected_modifiers"].(int)),
+			ModifierListID:       strPtr(info["modifier_list_id"].(string)),
+			ModifierOverrides:    []*squaremodel.CatalogModifierOverride{},
+		}
+
+		for _, rawOverride := range info["modifier_overrides"].([]interface{}) {
+			override := rawOverride.(map[string]interface{})
+			modifierListInfo.ModifierOverrides = append(modifierListInfo.ModifierOverrides, &squaremodel.CatalogModifierOverride{
+				ModifierID:  strPtr(override["modifier_id"].(string)),
+				OnByDefault: override["on_by_default"].(bool),
+			})
+		}
+
+		item.ModifierListInfo = append(item.ModifierListInfo, modifierListInfo)
+	}
+
+	obj.ItemData = item
+	obj.SetChildren(expandCatalogItemVariations(d, client.StringValue(obj.ID), existing))
+}
+
+// Square lists standard and custom dietary preferences and ingredients together, each with a type.
+func expandCatalogItemFoodAndBeverageDetails(details map[string]interface{}) map[string]interface{} {
+	entries := func(standardAttr, customAttr string) []interface{} {
+		result := []interface{}{}
+		for _, name := range sortedStrings(details[standardAttr].(*schema.Set)) {
+			result = append(result, map[string]interface{}{"type": "STANDARD", "standard_name": name})
+		}
+		for _, name := range sortedStrings(details[customAttr].(*schema.Set)) {
+			result = append(result, map[string]interface{}{"type": "CUSTOM", "custom_name": name})
+		}
+		return result
+	}
+
+	result := map[string]interface{}{
+		"dietary_preferences": entries("dietary_preferences", "custom_dietary_preferences"),
+		"ingredients":         entries("ingredients", "custom_ingredients"),
+	}
+	if calories := details["calorie_count"].(int); calories > 0 {
+		result["calorie_count"] = calories
+	}
+
+	return result
+}
+
+// Variation blocks are upserted along with their item, new ones without IDs, and are
+// present at the same locations as the item. Square deletes the variations of an item that are
+// left out of an upsert listing any, so an item without variation blocks lists none and leaves its
+// variations, such as those managed by item variation resources, alone.
+func expandCatalogItemVariations(d *schema.ResourceData, id string, existing []*client.CatalogObject) []*client.CatalogObject {
+	count := d.Get("variation.#").(int)
+	if count == 0 {
+		return nil
+	}
+
+	matched := matchCatalogItemVariations(d, existing)
+
+	// A variation is updated from the version last read, so that changes made since are detected
+	// as conflicts, unless it has yet to be read into a block.
+	o, _ := d.GetChange("variation")
+	versions := map[string]int64{}
+	for _, raw := range o.([]interface{}) {
+		block := raw.(map[string]interface{})
+		versions[block["id"].(string)] = int64(block["version"].(int))
+	}
+
+	variations := []*client.CatalogObject{}
+	for i := 0; i < count; i++ {
+		prefix := fmt.Sprintf("variation.%d.", i)
+
+		variation := expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+			Type:    strPtr(ItemVariationObjectType),
+			ImageID: d.Get(prefix + "image_id").(string),
+		})
+		if obj := matched[i]; obj != nil {
+			variation.ID = obj.ID
+			variation.Version = obj.Version
+			if version, ok := versions[*obj.ID]; ok {
+				variation.Version = version
+			}
+		}
+		expandCatalogItemVariation(d, prefix, id, variation)
+
+		// Block order is display order. Ordinals start at 1 because a zero ordinal is not sent.
+		variation.ItemVariationData.Ordinal = int64(i + 1)
+
+		variations = append(variations, variation)
+	}
+
+	return variations
+}
+
+// Returns the existing variation each variation block updates, by position in the configuration,
+// or nil for a block that adds one. A block updates the variation with its SKU or, failing that,
+// its name, so that blocks can be inserted and reordered. A block matching neither renames the
+// variation it replaced in the configuration, if no other block claimed that variation.
+func matchCatalogItemVariations(d *schema.ResourceData, existing []*client.CatalogObject) []*client.CatalogObject {
+	bySKU := map[string]*client.CatalogObject{}
+	byName := map[string]*client.CatalogObject{}
+	byID := map[string]*client.CatalogObject{}
+	for _, obj := range existing {
+		if obj.ItemVariationData == nil {
+			continue
+		}
+		if sku := obj.ItemVariationData.Sku; sku != "" {
+			bySKU[sku] = obj
+		}
+		byName[obj.ItemVariationData.Name] = obj
+		byID[client.StringValue(obj.ID)] = obj
+	}
+
+	o, n := d.GetChange("variation")
+	prior, configured := o.([]interface{}), n.([]interface{})
+
+	matched := make([]*client.CatalogObject, len(configured))
+	claimed := map[*client.CatalogObject]bool{}
+	for i, raw := range configured {
+		block := raw.(map[string]interface{})
+		obj := bySKU[block["sku"].(string)]
+		if obj == nil {
+			obj = byName[block["name"].(string)]
+		}
+		if obj != nil && !claimed[obj] {
+			matched[i] = obj
+			claimed[obj] = true
+		}
+	}
+
+	for i := range configured {
+		if matched[i] != nil || i >= len(prior) {
+			continue
+		}
+		if obj := byID[prior[i].(map[string]interface{})["id"].(string)]; obj != nil && !claimed[obj] {
+			matched[i] = obj
+			claimed[obj] = true
+		}
+	}
+
+	return matched
+}
+
+// Removing every variation block from an item deletes the variations the blocks managed, which an
+// upsert listing no variations would leave in Square.
+func deleteRemovedCatalogItemVariations(d *schema.ResourceData, meta interface{}) error {
+	if d.Get("variation.#").(int) > 0 {
+		return nil
+	}
+
+	o, _ := d.GetChange("variation")
+	for _, raw := range o.([]interface{}) {
+		id := raw.(map[string]interface{})["id"].(string)
+		if id == "" {
+			continue
+		}
+
+		if _, err := meta.(client.SquareAPI).DeleteCatalogObject(id); err != nil && !errors.Is(err, client.ErrNotFound) {
+			return fmt.Errorf("deleting variation %s: %w", id, err)
+		}
+	}
+
+	return nil
 }
 
-func flattenCatalogItem(item *squaremodel.CatalogItem, d *schema.ResourceData) error {
+func flattenCatalogItem(obj *client.CatalogObject, d *schema.ResourceData) error {
+	item := obj.ItemData
 	d.Set("abbreviation", item.Abbreviation)
 	d.Set("available_electronically", item.AvailableElectronically)
 	d.Set("available_for_pickup", item.AvailableForPickup)
 	d.Set("available_online", item.AvailableOnline)
 	d.Set("category_id", item.CategoryID)
-	d.Set("description", item.Description)
 	d.Set("label_color", item.LabelColor)
+	d.Set("item_options", flattenCatalogItemOptions(item.ItemOptions))
+	d.Set("modifier_list_info", flattenCatalogItemModifierListInfo(item.ModifierListInfo))
 	d.Set("name", item.Name)
+	d.Set("product_type", item.ProductType)
 	d.Set("skip_modifier_screen", item.SkipModifierScreen)
 	d.Set("tax_ids", item.TaxIds)
 
+	field := func(name string) interface{} {
+		value, _ := obj.Field("item_data." + name)
+		return value
+	}
+
+	html, _ := field("description_html").(string)
+	d.Set("description_html", html)
+
+	// Square derives a plain text description from an HTML one, which is not configured.
+	if html == "" || d.Get("description").(string) != "" {
+		d.Set("description", item.Description)
+	}
+
+	channels, _ := field("channels").([]interface{})
+	d.Set("channels", channels)
+	imageIDs, _ := field("image_ids").([]interface{})
+	d.Set("image_ids", imageIDs)
+	visibility, _ := field("ecom_visibility").(string)
+	d.Set("ecom_visibility", visibility)
+	sortName, _ := field("sort_name").(string)
+	d.Set("sort_name", sortName)
+
+	// Square treats an item it has no setting for as taxable.
+	taxable, ok := field("is_taxable").(bool)
+	d.Set("is_taxable", taxable || !ok)
+
+	reportingCategory, _ := field("reporting_category").(map[string]interface{})
+	reportingCategoryID, _ := reportingCategory["id"].(string)
+	d.Set("reporting_category_id", reportingCategoryID)
+
+	details, _ := field("food_and_beverage_details").(map[string]interface{})
+	d.Set("food_and_beverage_details", flattenCatalogItemFoodAndBeverageDetails(details))
+
+	// The variations of an item without variation blocks are left to item variation resources.
+	if d.Get("variation.#").(int) > 0 {
+		d.Set("variation", flattenCatalogItemVariations(obj.Children()))
+	}
+
 	return nil
 }
+
+func flattenCatalogItemFoodAndBeverageDetails(details map[string]interface{}) []interface{} {
+	if details == nil {
+		return []interface{}{}
+	}
+
+	names := func(attr string) (standard, custom []interface{}) {
+		entries, _ := details[attr].([]interface{})
+		for _, e := range entries {
+			entry, _ := e.(map[string]interface{})
+			if entry["type"] == "CUSTOM" {
+				custom = append(custom, entry["custom_name"])
+			} else {
+				standard = append(standard, entry["standard_name"])
+			}
+		}
+		return
+	}
+
+	calories, _ := details["calorie_count"].(float64)
+	preferences, customPreferences := names("dietary_preferences")
+	ingredients, customIngredients := names("ingredients")
+
+	return []interface{}{
+		map[string]interface{}{
+			"calorie_count":              int(calories),
+			"custom_dietary_preferences": customPreferences,
+			"custom_ingredients":         customIngredients,
+			"dietary_preferences":        preferences,
+			"ingredients":                ingredients,
+		},
+	}
+}
+
+// Flattens an item's variations in the order Square displays them, which is the order they were
+// last upserted in.
+func flattenCatalogItemVariations(variations []*client.CatalogObject) []interface{} {
+	variations = append([]*client.CatalogObject{}, variations...)
+	sort.SliceStable(variations, func(i, j int) bool {
+		return variationOrdinal(variations[i]) < variationOrdinal(variations[j])
+	})
+
+	result := []interface{}{}
+	for _, obj := range variations {
+		if obj.ItemVariationData == nil {
+			continue
+		}
+
+		variation := catalogItemVariationAttributes(obj)
+		variation["id"] = client.StringValue(obj.ID)
+		variation["image_id"] = obj.ImageID
+		variation["version"] = int(obj.Version)
+		result = append(result, variation)
+	}
+
+	return result
+}
+
+func variationOrdinal(obj *client.CatalogObject) int64 {
+	if obj.ItemVariationData == nil {
+		return 0
+	}
+
+	return obj.ItemVariationData.Ordinal
+}
+
+func flattenCatalogItemOptions(itemOptions []*squaremodel.CatalogItemOptionForItem) []interface{} {
+	ids := []interface{}{}
+	for _, option := range itemOptions {
+		ids = append(ids, option.ItemOptionID)
+	}
+
+	return ids
+}
+
+func flattenCatalogItemModifierListInfo(modifierListInfo []*squaremodel.CatalogItemModifierListInfo) []interface{} {
+	infos := []interface{}{}
+	for _, info := range modifierListInfo {
+		overrides := []interface{}{}
+		for _, override := range info.ModifierOverrides {
+			overrides = append(overrides, map[string]interface{}{
+				"modifier_id":   client.StringValue(override.ModifierID),
+				"on_by_default": override.OnByDefault,
+			})
+		}
+
+		infos = append(infos, map[string]interface{}{
+			"enabled":                info.Enabled,
+			"max_selected_modifiers": info.MaxSelectedModifiers,
+			"min_selected_modifiers": info.MinSelectedModifiers,
+			"modifier_list_id":       client.StringValue(info.ModifierListID),
+			"modifier_overrides":     overrides,
+		})
+	}
+
+	return infos
+}
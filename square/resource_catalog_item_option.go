@@ -0,0 +1,272 @@
+package square
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	squaremodel "github.com/jefflinse/square-connect/models"
+	"github.com/jefflinse/terraform-provider-square/square/client"
+)
+
+const (
+	// CatalogItemOptionNameMaxLength is the maximum length for an item option's name.
+	CatalogItemOptionNameMaxLength = 255
+
+	// CatalogItemOptionValueNameMaxLength is the maximum length for an item option value's name.
+	CatalogItemOptionValueNameMaxLength = 255
+
+	// ItemOptionObjectType is the Square type for a catalog object describing an item option.
+	ItemOptionObjectType = "ITEM_OPTION"
+
+	// ItemOptionValueObjectType is the Square type for a catalog object describing an item option value.
+	ItemOptionValueObjectType = "ITEM_OPTION_VAL"
+)
+
+func resourceSquareCatalogItemOption() *schema.Resource {
+	return &schema.Resource{
+		Schema: withIdempotencyKey(withLocationAttributes(map[string]*schema.Schema{
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(v interface{}, k string) (wrns []string, errs []error) {
+					val := v.(string)
+					if len(val) > CatalogItemOptionNameMaxLength {
+						errs = append(errs, fmt.Errorf("item option name '%s' exceeds max length of %d", val, CatalogItemOptionNameMaxLength))
+					}
+					return
+				},
+			},
+			"show_colors": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"values": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"color": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: func(v interface{}, k string) (wrns []string, errs []error) {
+								val := v.(string)
+								if len(val) > CatalogItemOptionValueNameMaxLength {
+									errs = append(errs, fmt.Errorf("item option value name '%s' exceeds max length of %d", val, CatalogItemOptionValueNameMaxLength))
+								}
+								return
+							},
+						},
+						"ordinal": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							// Values without an ordinal are ordered as they are configured.
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								return new == "0"
+							},
+						},
+					},
+				},
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		})),
+		Create:        resourceSquareCatalogItemOptionCreate,
+		Read:          resourceSquareCatalogItemOptionRead,
+		Update:        resourceSquareCatalogItemOptionUpdate,
+		Delete:        resourceSquareCatalogItemOptionDelete,
+		CustomizeDiff: validateCatalogObjectLocations,
+		Importer:      importCatalogObject(ItemOptionObjectType),
+	}
+}
+
+func resourceSquareCatalogItemOptionCreate(d *schema.ResourceData, meta interface{}) error {
+	obj := expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+		ID:   newCatalogObjectID(d),
+		Type: strPtr(ItemOptionObjectType),
+	})
+	expandCatalogItemOption(d, obj)
+
+	created, err := meta.(client.SquareAPI).UpsertCatalogObject(obj)
+	if err != nil {
+		return attributeErrors(err, nil)
+	}
+
+	d.SetId(*created.ID)
+
+	return resourceSquareCatalogItemOptionRead(d, meta)
+}
+
+func resourceSquareCatalogItemOptionRead(d *schema.ResourceData, meta interface{}) error {
+	obj, err := meta.(client.SquareAPI).RetrieveCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		log.Printf("[WARN] catalog item option %s no longer exists, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	d.Set("version", obj.Version)
+	flattenCatalogObjectLocations(obj, d)
+
+	return flattenCatalogItemOption(obj.ItemOptionData, d)
+}
+
+func resourceSquareCatalogItemOptionUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("absent_at_location_ids") ||
+		d.HasChange("description") ||
+		d.HasChange("display_name") ||
+		d.HasChange("name") ||
+		d.HasChange("present_at_all_locations") ||
+		d.HasChange("present_at_location_ids") ||
+		d.HasChange("show_colors") ||
+		d.HasChange("values") {
+
+		obj := expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+			ID:      strPtr(d.Id()),
+			Type:    strPtr(ItemOptionObjectType),
+			Version: int64(d.Get("version").(int)),
+		})
+		expandCatalogItemOption(d, obj)
+
+		if _, err := meta.(client.SquareAPI).UpsertCatalogObject(obj); err != nil {
+			return attributeErrors(err, nil)
+		}
+	}
+
+	return resourceSquareCatalogItemOptionRead(d, meta)
+}
+
+func resourceSquareCatalogItemOptionDelete(d *schema.ResourceData, meta interface{}) error {
+	_, err := meta.(client.SquareAPI).DeleteCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// Values keep the ID they were given when first created, so variations bound to a value stay
+// bound when values are added, removed, or reordered around it. A configured value is matched to
+// the value of the same name, or else renames the value formerly at its position if that value's
+// name is no longer configured. Values no longer configured are deleted by Square.
+func expandCatalogItemOption(d *schema.ResourceData, obj *client.CatalogObject) {
+	obj.ItemOptionData = &squaremodel.CatalogItemOption{
+		Description: d.Get("description").(string),
+		DisplayName: d.Get("display_name").(string),
+		Name:        d.Get("name").(string),
+		ShowColors:  d.Get("show_colors").(bool),
+	}
+
+	ids := itemOptionValueIDs(d)
+
+	values := []*client.CatalogObject{}
+	for i, raw := range d.Get("values").([]interface{}) {
+		value := raw.(map[string]interface{})
+
+		// Ordinals start at 1 because a zero ordinal is not sent.
+		ordinal := value["ordinal"].(int)
+		if ordinal == 0 {
+			ordinal = i + 1
+		}
+
+		// Values are present at the same locations as their option.
+		values = append(values, expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+			ID:   strPtr(ids[i]),
+			Type: strPtr(ItemOptionValueObjectType),
+			ItemOptionValueData: &squaremodel.CatalogItemOptionValue{
+				Color:        value["color"].(string),
+				Description:  value["description"].(string),
+				ItemOptionID: d.Id(),
+				Name:         value["name"].(string),
+				Ordinal:      int64(ordinal),
+			},
+		}))
+	}
+
+	obj.SetChildren(values)
+}
+
+// Returns the IDs of the existing values the configured values update, by position in the
+// configuration; new values get an empty ID.
+func itemOptionValueIDs(d *schema.ResourceData) []string {
+	o, n := d.GetChange("values")
+	prior, configured := o.([]interface{}), n.([]interface{})
+
+	priorIDs := map[string]string{}
+	for _, raw := range prior {
+		value := raw.(map[string]interface{})
+		priorIDs[value["name"].(string)] = value["id"].(string)
+	}
+
+	configuredNames := map[string]bool{}
+	for _, raw := range configured {
+		configuredNames[raw.(map[string]interface{})["name"].(string)] = true
+	}
+
+	ids := make([]string, len(configured))
+	for i, raw := range configured {
+		name := raw.(map[string]interface{})["name"].(string)
+		if id, ok := priorIDs[name]; ok {
+			ids[i] = id
+		} else if i < len(prior) {
+			if renamed := prior[i].(map[string]interface{}); !configuredNames[renamed["name"].(string)] {
+				ids[i] = renamed["id"].(string)
+			}
+		}
+	}
+
+	return ids
+}
+
+func flattenCatalogItemOption(itemOption *squaremodel.CatalogItemOption, d *schema.ResourceData) error {
+	d.Set("description", itemOption.Description)
+	d.Set("display_name", itemOption.DisplayName)
+	d.Set("name", itemOption.Name)
+	d.Set("show_colors", itemOption.ShowColors)
+
+	values := []interface{}{}
+	for _, obj := range itemOption.Values {
+		if obj.ItemOptionValueData == nil {
+			continue
+		}
+
+		values = append(values, map[string]interface{}{
+			"color":       obj.ItemOptionValueData.Color,
+			"description": obj.ItemOptionValueData.Description,
+			"id":          client.StringValue(obj.ID),
+			"name":        obj.ItemOptionValueData.Name,
+			"ordinal":     obj.ItemOptionValueData.Ordinal,
+		})
+	}
+	d.Set("values", values)
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package square
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccSquareCatalogItemOption_update(t *testing.T) {
+	var id, smallID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_item_option"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogItemOptionConfig("Size", false, `
+  values {
+    name    = "Small"
+    ordinal = 1
+  }
+
+  values {
+    name    = "Large"
+    ordinal = 2
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_item_option.test"),
+					testAccCheckResourceID("square_catalog_item_option.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_item_option.test", "name", "Size"),
+					resource.TestCheckResourceAttr("square_catalog_item_option.test", "show_colors", "false"),
+					resource.TestCheckResourceAttr("square_catalog_item_option.test", "values.#", "2"),
+					resource.TestCheckResourceAttr("square_catalog_item_option.test", "values.0.name", "Small"),
+					resource.TestCheckResourceAttr("square_catalog_item_option.test", "values.0.ordinal", "1"),
+					resource.TestCheckResourceAttr("square_catalog_item_option.test", "values.1.name", "Large"),
+					resource.TestCheckResourceAttrSet("square_catalog_item_option.test", "version"),
+					testAccCheckBlockID("square_catalog_item_option.test", "values", 0, &smallID),
+				),
+			},
+			{
+				Config: testAccSquareCatalogItemOptionConfig("Color", true, `
+  values {
+    name  = "Red"
+    color = "FF0000"
+  }
+
+  values {
+    name        = "Blue"
+    color       = "0000FF"
+    description = "Navy blue"
+  }
+
+  values {
+    name  = "Green"
+    color = "00FF00"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_item_option.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_item_option.test", "name", "Color"),
+					resource.TestCheckResourceAttr("square_catalog_item_option.test", "show_colors", "true"),
+					resource.TestCheckResourceAttr("square_catalog_item_option.test", "values.#", "3"),
+					resource.TestCheckResourceAttr("square_catalog_item_option.test", "values.0.name", "Red"),
+					resource.TestCheckResourceAttr("square_catalog_item_option.test", "values.0.color", "FF0000"),
+					resource.TestCheckResourceAttr("square_catalog_item_option.test", "values.1.description", "Navy blue"),
+					resource.TestCheckResourceAttr("square_catalog_item_option.test", "values.2.name", "Green"),
+					testAccCheckBlockID("square_catalog_item_option.test", "values", 0, &smallID),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_item_option.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogItemOption_insertValue(t *testing.T) {
+	var smallID, largeID, mediumID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_item_option"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogItemOptionConfig("Size", false, `
+  values {
+    name = "Small"
+  }
+
+  values {
+    name = "Large"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBlockID("square_catalog_item_option.test", "values", 0, &smallID),
+					testAccCheckBlockID("square_catalog_item_option.test", "values", 1, &largeID),
+				),
+			},
+			{
+				Config: testAccSquareCatalogItemOptionConfig("Size", false, `
+  values {
+    name = "Small"
+  }
+
+  values {
+    name = "Medium"
+  }
+
+  values {
+    name = "Large"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_catalog_item_option.test", "values.#", "3"),
+					resource.TestCheckResourceAttr("square_catalog_item_option.test", "values.1.name", "Medium"),
+					resource.TestCheckResourceAttr("square_catalog_item_option.test", "values.2.name", "Large"),
+					testAccCheckBlockID("square_catalog_item_option.test", "values", 0, &smallID),
+					testAccCheckBlockID("square_catalog_item_option.test", "values", 2, &largeID),
+					testAccCheckBlockID("square_catalog_item_option.test", "values", 1, &mediumID),
+					func(s *terraform.State) error {
+						if mediumID == smallID || mediumID == largeID {
+							return fmt.Errorf("inserted value took the ID %s of an existing value", mediumID)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// Removing a value leaves the values after it alone.
+				Config: testAccSquareCatalogItemOptionConfig("Size", false, `
+  values {
+    name = "Medium"
+  }
+
+  values {
+    name = "Large"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_catalog_item_option.test", "values.#", "2"),
+					testAccCheckBlockID("square_catalog_item_option.test", "values", 0, &mediumID),
+					testAccCheckBlockID("square_catalog_item_option.test", "values", 1, &largeID),
+				),
+			},
+		},
+	})
+}
+
+func testAccSquareCatalogItemOptionConfig(name string, showColors bool, values string) string {
+	return fmt.Sprintf(`
+resource "square_catalog_item_option" "test" {
+  name        = %q
+  show_colors = %t
+%s
+}
+`, name, showColors, values)
+}
@@ -0,0 +1,546 @@
+package square
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	squaremodel "github.com/jefflinse/square-connect/models"
+	"github.com/jefflinse/terraform-provider-square/square/client"
+)
+
+func TestAccSquareCatalogItem_update(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_item"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCatalogItemConfig("test", "T-shirt"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_item.test"),
+					testAccCheckResourceID("square_catalog_item.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "name", "T-shirt"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "ecom_visibility", "UNINDEXED"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "is_taxable", "true"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "product_type", "REGULAR"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "skip_modifier_screen", "false"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "tax_ids.#", "0"),
+					resource.TestCheckResourceAttrSet("square_catalog_item.test", "version"),
+				),
+			},
+			{
+				Config: testAccSquareCatalogItemConfigFull("Tee"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_item.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "name", "Tee"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "abbreviation", "TS"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "available_electronically", "true"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "available_for_pickup", "true"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "available_online", "true"),
+					resource.TestCheckResourceAttrPair("square_catalog_item.test", "category_id", "square_catalog_category.test", "id"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "channels.#", "1"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "description", "Our regular t-shirt"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "ecom_visibility", "VISIBLE"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "is_taxable", "false"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "label_color", "0000FF"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "product_type", "REGULAR"),
+					resource.TestCheckResourceAttrPair("square_catalog_item.test", "reporting_category_id", "square_catalog_category.test", "id"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "skip_modifier_screen", "true"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "sort_name", "tee shirt"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "tax_ids.#", "1"),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_item.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogItem_clearAttributes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_item"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogItemConfigFull("Tee"),
+			},
+			{
+				// Removing an attribute from the configuration resets it rather than leaving it as it was.
+				Config: testAccCatalogItemConfig("test", "T-shirt"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_catalog_item.test", "abbreviation", ""),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "category_id", ""),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "is_taxable", "true"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "reporting_category_id", ""),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "skip_modifier_screen", "false"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "tax_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogItem_modifierListInfo(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_item"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogItemConfigModifierListInfo(true, -1, -1, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_item.test"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "modifier_list_info.#", "1"),
+					resource.TestCheckResourceAttrPair("square_catalog_item.test", "modifier_list_info.0.modifier_list_id", "square_catalog_modifier_list.test", "id"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "modifier_list_info.0.enabled", "true"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "modifier_list_info.0.min_selected_modifiers", "-1"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "modifier_list_info.0.max_selected_modifiers", "-1"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "modifier_list_info.0.modifier_overrides.#", "1"),
+					resource.TestCheckResourceAttrPair("square_catalog_item.test", "modifier_list_info.0.modifier_overrides.0.modifier_id", "square_catalog_modifier.test", "id"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "modifier_list_info.0.modifier_overrides.0.on_by_default", "false"),
+				),
+			},
+			{
+				Config: testAccSquareCatalogItemConfigModifierListInfo(false, 0, 2, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_catalog_item.test", "modifier_list_info.0.enabled", "false"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "modifier_list_info.0.min_selected_modifiers", "0"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "modifier_list_info.0.max_selected_modifiers", "2"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "modifier_list_info.0.modifier_overrides.0.on_by_default", "true"),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_item.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+			{
+				Config: testAccCatalogItemConfig("test", "Latte"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_catalog_item.test", "modifier_list_info.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogItem_variations(t *testing.T) {
+	var smallID, largeID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_item"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogItemConfigVariations(
+					testAccSquareCatalogItemVariationBlock("Small", 300),
+					testAccSquareCatalogItemVariationBlock("Large", 450),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_item.test"),
+					testAccCheckCatalogItemVariations("square_catalog_item.test", "Small", "Large"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "variation.#", "2"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "variation.1.price", "450"),
+					resource.TestCheckResourceAttrSet("square_catalog_item.test", "variation.0.version"),
+					testAccCheckBlockID("square_catalog_item.test", "variation", 0, &smallID),
+					testAccCheckBlockID("square_catalog_item.test", "variation", 1, &largeID),
+				),
+			},
+			{
+				// A block matching no variation renames the one it replaced.
+				Config: testAccSquareCatalogItemConfigVariations(
+					testAccSquareCatalogItemVariationBlock("Regular", 300),
+					testAccSquareCatalogItemVariationBlock("Large", 500),
+					testAccSquareCatalogItemVariationBlock("Extra Large", 650),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogItemVariations("square_catalog_item.test", "Regular", "Large", "Extra Large"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "variation.#", "3"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "variation.1.price", "500"),
+					testAccCheckBlockID("square_catalog_item.test", "variation", 0, &smallID),
+					testAccCheckBlockID("square_catalog_item.test", "variation", 1, &largeID),
+				),
+			},
+			{
+				// Variations are imported by writing blocks for them, not as blocks.
+				ResourceName:            "square_catalog_item.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key", "variation"},
+			},
+			{
+				// Blocks are matched to variations by name, so inserting one adds a variation.
+				Config: testAccSquareCatalogItemConfigVariations(
+					testAccSquareCatalogItemVariationBlock("Small", 250),
+					testAccSquareCatalogItemVariationBlock("Regular", 300),
+					testAccSquareCatalogItemVariationBlock("Large", 500),
+					testAccSquareCatalogItemVariationBlock("Extra Large", 650),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogItemVariations("square_catalog_item.test", "Small", "Regular", "Large", "Extra Large"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "variation.0.price", "250"),
+					testAccCheckBlockID("square_catalog_item.test", "variation", 1, &smallID),
+					testAccCheckBlockID("square_catalog_item.test", "variation", 2, &largeID),
+				),
+			},
+			{
+				// Block order is the order Square displays the variations in.
+				Config: testAccSquareCatalogItemConfigVariations(
+					testAccSquareCatalogItemVariationBlock("Extra Large", 650),
+					testAccSquareCatalogItemVariationBlockSKU("Large", "COFFEE-L", 500),
+					testAccSquareCatalogItemVariationBlock("Regular", 300),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogItemVariations("square_catalog_item.test", "Extra Large", "Large", "Regular"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "variation.0.name", "Extra Large"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "variation.2.name", "Regular"),
+					testAccCheckBlockID("square_catalog_item.test", "variation", 1, &largeID),
+					testAccCheckBlockID("square_catalog_item.test", "variation", 2, &smallID),
+				),
+			},
+			{
+				// A block is matched by its SKU before its name.
+				Config: testAccSquareCatalogItemConfigVariations(
+					testAccSquareCatalogItemVariationBlockSKU("Grande", "COFFEE-L", 500),
+					testAccSquareCatalogItemVariationBlock("Regular", 300),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogItemVariations("square_catalog_item.test", "Grande", "Regular"),
+					testAccCheckBlockID("square_catalog_item.test", "variation", 0, &largeID),
+					testAccCheckBlockID("square_catalog_item.test", "variation", 1, &smallID),
+				),
+			},
+			{
+				// Removing every block deletes the variations the blocks managed.
+				Config: testAccSquareCatalogItemConfigVariations(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogItemVariations("square_catalog_item.test"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "variation.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogItem_adoptVariations(t *testing.T) {
+	var itemID, variationID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_item"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogItemConfigVariations(),
+				Check:  testAccCheckResourceID("square_catalog_item.test", &itemID, false),
+			},
+			{
+				// A variation the item already has is taken over by a block with its name.
+				PreConfig: func() {
+					created, err := testAccProvider.Meta().(client.SquareAPI).UpsertCatalogObject(client.NewCatalogObject(&squaremodel.CatalogObject{
+						Type: strPtr(ItemVariationObjectType),
+						ItemVariationData: &squaremodel.CatalogItemVariation{
+							ItemID:      itemID,
+							Name:        "Small",
+							PricingType: PricingTypeVariable,
+						},
+					}))
+					if err != nil {
+						t.Fatal(err)
+					}
+					variationID = *created.ID
+				},
+				Config: testAccSquareCatalogItemConfigVariations(
+					testAccSquareCatalogItemVariationBlock("Small", 300),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogItemVariations("square_catalog_item.test", "Small"),
+					testAccCheckBlockID("square_catalog_item.test", "variation", 0, &variationID),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "variation.0.price", "300"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogItem_changeProductType(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_item"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCatalogItemConfig("test", "Latte"),
+				Check:  testAccCheckResourceID("square_catalog_item.test", &id, false),
+			},
+			{
+				// Square cannot change an item's product type, so the item is replaced.
+				Config: `
+resource "square_catalog_item" "test" {
+  name         = "Latte"
+  product_type = "FOOD_AND_BEV"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_item.test", &id, true),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "product_type", "FOOD_AND_BEV"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogItem_foodAndBeverage(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_item"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogItemConfigFoodAndBeverage(350, `["VEGAN"]`, `["SOY", "TREE_NUTS"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_item.test"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "product_type", "FOOD_AND_BEV"),
+					resource.TestCheckNoResourceAttr("square_catalog_item.test", "description"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "description_html", "<p>Oat milk <b>latte</b></p>"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "food_and_beverage_details.#", "1"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "food_and_beverage_details.0.calorie_count", "350"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "food_and_beverage_details.0.dietary_preferences.#", "1"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "food_and_beverage_details.0.custom_dietary_preferences.#", "1"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "food_and_beverage_details.0.ingredients.#", "2"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "food_and_beverage_details.0.custom_ingredients.#", "1"),
+				),
+			},
+			{
+				Config: testAccSquareCatalogItemConfigFoodAndBeverage(0, `[]`, `["SOY"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_catalog_item.test", "food_and_beverage_details.0.calorie_count", "0"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "food_and_beverage_details.0.dietary_preferences.#", "0"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "food_and_beverage_details.0.ingredients.#", "1"),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_item.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogItem_invalid(t *testing.T) {
+	testAccRunRejectedConfigs(t, []testAccRejectedConfig{
+		{
+			name: "unsupported product type",
+			config: `
+resource "square_catalog_item" "test" {
+  name         = "Gift"
+  product_type = "GIFT"
+}
+`,
+			err: "expected product_type to be one of",
+		},
+		{
+			name: "description and description_html",
+			config: `
+resource "square_catalog_item" "test" {
+  name             = "Latte"
+  description      = "Oat milk latte"
+  description_html = "<p>Oat milk latte</p>"
+}
+`,
+			err: `"description": conflicts with description_html`,
+		},
+		{
+			name: "food and beverage details on a regular item",
+			config: `
+resource "square_catalog_item" "test" {
+  name = "Latte"
+
+  food_and_beverage_details {
+    ingredients = ["MILK"]
+  }
+}
+`,
+			err: "food_and_beverage_details can only be set with product_type FOOD_AND_BEV, not REGULAR",
+		},
+		{
+			name:   "unsupported dietary preference",
+			config: testAccSquareCatalogItemConfigFoodAndBeverage(350, `["PALEO"]`, `[]`),
+			err:    `expected food_and_beverage_details.0.dietary_preferences.\d+ to be one of`,
+		},
+		{
+			name: "priced variable variation",
+			config: testAccSquareCatalogItemConfigVariations(
+				testAccSquareCatalogItemVariationBlock("Small", 300),
+				`
+  variation {
+    name         = "Market Price"
+    pricing_type = "VARIABLE_PRICING"
+    price        = 100
+  }
+`),
+			err: "variation 1: price and currency cannot be set with VARIABLE_PRICING",
+		},
+		{
+			name: "service variation of a regular item",
+			config: testAccSquareCatalogItemConfigVariations(`
+  variation {
+    name             = "Small"
+    pricing_type     = "VARIABLE_PRICING"
+    service_duration = "30m"
+  }
+`),
+			err: "variation 0: service_duration can only be set on a variation of an APPOINTMENTS_SERVICE item, not REGULAR",
+		},
+		{
+			// Square's error for the second variation is reported for its block.
+			name: "variation with unknown measurement unit",
+			config: testAccSquareCatalogItemConfigVariations(testAccSquareCatalogItemVariationBlock("Small", 300), `
+  variation {
+    name                = "By the pound"
+    pricing_type        = "VARIABLE_PRICING"
+    measurement_unit_id = "NOSUCHUNIT"
+  }
+`),
+			err: "variation.1.measurement_unit_id: INVALID_VALUE",
+		},
+	})
+}
+
+// Verifies that the named item has variations with the specified names in Square, in order.
+func testAccCheckCatalogItemVariations(name string, names ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+
+		obj, err := testAccProvider.Meta().(client.SquareAPI).RetrieveCatalogObject(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		actual := []string{}
+		for _, v := range obj.ItemData.Variations {
+			actual = append(actual, v.ItemVariationData.Name)
+		}
+
+		if strings.Join(actual, ", ") != strings.Join(names, ", ") {
+			return fmt.Errorf("%s has variations %v, want %v", name, actual, names)
+		}
+
+		return nil
+	}
+}
+
+func testAccSquareCatalogItemConfigFull(name string) string {
+	return testAccCatalogCategoryConfig("test", "Apparel") +
+		testAccSquareCatalogTaxConfig("Sales Tax", "TAX_SUBTOTAL_PHASE", "ADDITIVE", "8.5", false, false) + fmt.Sprintf(`
+resource "square_catalog_item" "test" {
+  name                     = %q
+  abbreviation             = "TS"
+  available_electronically = true
+  available_for_pickup     = true
+  available_online         = true
+  category_id              = square_catalog_category.test.id
+  channels                 = ["CH_ONLINE"]
+  description              = "Our regular t-shirt"
+  ecom_visibility          = "VISIBLE"
+  is_taxable               = false
+  label_color              = "0000FF"
+  reporting_category_id    = square_catalog_category.test.id
+  skip_modifier_screen     = true
+  sort_name                = "tee shirt"
+  tax_ids                  = [square_catalog_tax.test.id]
+}
+`, name)
+}
+
+func testAccSquareCatalogItemConfigFoodAndBeverage(calories int, preferences, ingredients string) string {
+	return fmt.Sprintf(`
+resource "square_catalog_item" "test" {
+  name             = "Latte"
+  product_type     = "FOOD_AND_BEV"
+  description_html = "<p>Oat milk <b>latte</b></p>"
+
+  food_and_beverage_details {
+    calorie_count              = %d
+    dietary_preferences        = %s
+    custom_dietary_preferences = ["Low sugar"]
+    ingredients                = %s
+    custom_ingredients         = ["Oats"]
+  }
+}
+`, calories, preferences, ingredients)
+}
+
+func testAccSquareCatalogItemConfigModifierListInfo(enabled bool, min, max int, onByDefault bool) string {
+	return testAccCatalogModifierListConfig("test", "Milk choice") + fmt.Sprintf(`
+resource "square_catalog_modifier" "test" {
+  modifier_list_id = square_catalog_modifier_list.test.id
+  name             = "Oat milk"
+}
+
+resource "square_catalog_item" "test" {
+  name = "Latte"
+
+  modifier_list_info {
+    modifier_list_id       = square_catalog_modifier_list.test.id
+    enabled                = %t
+    min_selected_modifiers = %d
+    max_selected_modifiers = %d
+
+    modifier_overrides {
+      modifier_id   = square_catalog_modifier.test.id
+      on_by_default = %t
+    }
+  }
+}
+`, enabled, min, max, onByDefault)
+}
+
+func testAccSquareCatalogItemConfigVariations(variations ...string) string {
+	return fmt.Sprintf(`
+resource "square_catalog_item" "test" {
+  name = "Coffee"
+%s
+}
+`, strings.Join(variations, ""))
+}
+
+func testAccSquareCatalogItemVariationBlock(name string, price int) string {
+	return fmt.Sprintf(`
+  variation {
+    name         = %q
+    pricing_type = "FIXED_PRICING"
+    price        = %d
+    currency     = "USD"
+  }
+`, name, price)
+}
+
+func testAccSquareCatalogItemVariationBlockSKU(name, sku string, price int) string {
+	return fmt.Sprintf(`
+  variation {
+    name         = %q
+    sku          = %q
+    pricing_type = "FIXED_PRICING"
+    price        = %d
+    currency     = "USD"
+  }
+`, name, sku, price)
+}
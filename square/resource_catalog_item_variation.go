@@ -1,14 +1,27 @@
 package square
 
 import (
+	"errors"
 	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
 
+	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	squaremodel "github.com/jefflinse/square-connect/models"
 	"github.com/jefflinse/terraform-provider-square/square/client"
 )
 
 const (
+	// InventoryAlertTypeLowQuantity designates an alert when an item variation's inventory falls to its threshold.
+	InventoryAlertTypeLowQuantity = "LOW_QUANTITY"
+
+	// InventoryAlertTypeNone designates no inventory alerts for an item variation.
+	InventoryAlertTypeNone = "NONE"
+
 	// CatalogItemVariationNameMaxLength is the maximum length for an item variation's name.
 	CatalogItemVariationNameMaxLength = 255
 
@@ -22,60 +35,367 @@ const (
 	PricingTypeVariable = "VARIABLE_PRICING"
 )
 
+// Maps Square item variation fields to the attributes they are configured by.
+var catalogItemVariationFields = map[string]string{
+	"no_show_fee.amount":   "no_show_fee",
+	"no_show_fee.currency": "currency",
+	"price_money.amount":   "price",
+	"price_money.currency": "currency",
+}
+
+// catalogItemVariationServiceAttributes are the attributes only a variation of an
+// APPOINTMENTS_SERVICE item can set.
+var catalogItemVariationServiceAttributes = []string{"available_for_booking", "no_show_fee", "service_duration", "team_member_ids"}
+
 func resourceSquareCatalogItemVariation() *schema.Resource {
+	s := catalogItemVariationSchema()
+	s["image_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+	}
+	s["item_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	s["version"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Computed: true,
+	}
+
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
-			"currency": {
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-			"item_id": {
-				Type:     schema.TypeString,
-				Required: true,
+		Schema:        withIdempotencyKey(withLocationAttributes(s)),
+		Create:        resourceSquareCatalogItemVariationCreate,
+		Read:          resourceSquareCatalogItemVariationRead,
+		Update:        resourceSquareCatalogItemVariationUpdate,
+		Delete:        resourceSquareCatalogItemVariationDelete,
+		CustomizeDiff: resourceSquareCatalogItemVariationCustomizeDiff,
+		Importer:      importCatalogObject(ItemVariationObjectType),
+	}
+}
+
+// Returns the attributes describing an item variation, which are shared by the item variation
+// resource and the variation blocks of an item.
+func catalogItemVariationSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"available_for_booking": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"currency": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"inventory_alert_threshold": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntBetween(0, math.MaxInt32),
+		},
+		"inventory_alert_type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      InventoryAlertTypeNone,
+			ValidateFunc: validation.StringInSlice([]string{InventoryAlertTypeLowQuantity, InventoryAlertTypeNone}, false),
+		},
+		"item_option_values": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"item_option_id": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"item_option_value_id": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+				},
 			},
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-				ValidateFunc: func(v interface{}, k string) (wrns []string, errs []error) {
-					val := v.(string)
-					if len(val) > CatalogItemVariationNameMaxLength {
-						errs = append(errs, fmt.Errorf("item variation name '%s' exceeds max length of %d", val, CatalogItemVariationNameMaxLength))
-					}
-					return
+		},
+		"location_override": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Set:      hashItemVariationLocationOverride,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"inventory_alert_threshold": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						ValidateFunc: validation.IntBetween(0, math.MaxInt32),
+					},
+					"inventory_alert_type": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      InventoryAlertTypeNone,
+						ValidateFunc: validation.StringInSlice([]string{InventoryAlertTypeLowQuantity, InventoryAlertTypeNone}, false),
+					},
+					"location_id": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"price": {
+						Type:     schema.TypeInt,
+						Optional: true,
+					},
+					"pricing_type": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice([]string{PricingTypeFixed, PricingTypeVariable}, false),
+					},
+					"sold_out": {
+						Type:     schema.TypeBool,
+						Computed: true,
+					},
+					"track_inventory": {
+						Type:     schema.TypeBool,
+						Optional: true,
+					},
 				},
 			},
-			"price": {
-				Type:     schema.TypeInt,
-				Optional: true,
+		},
+		"measurement_unit_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			ValidateFunc: func(v interface{}, k string) (wrns []string, errs []error) {
+				val := v.(string)
+				if len(val) > CatalogItemVariationNameMaxLength {
+					errs = append(errs, fmt.Errorf("item variation name '%s' exceeds max length of %d", val, CatalogItemVariationNameMaxLength))
+				}
+				return
 			},
-			"pricing_type": {
-				Type:     schema.TypeString,
-				Required: true,
+		},
+		"no_show_fee": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntBetween(0, math.MaxInt32),
+		},
+		"price": {
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+		"pricing_type": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice([]string{PricingTypeFixed, PricingTypeVariable}, false),
+		},
+		"sellable": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"service_duration": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ValidateFunc: func(v interface{}, k string) (wrns []string, errs []error) {
+				val := v.(string)
+				if duration, err := time.ParseDuration(val); err != nil || duration <= 0 || duration%time.Millisecond != 0 {
+					errs = append(errs, fmt.Errorf("%s '%s' is not a positive duration in whole milliseconds, e.g. \"45m\"", k, val))
+				}
+				return
 			},
-			"sku": {
-				Type:     schema.TypeString,
-				Optional: true,
+			// Square stores a duration in milliseconds, so "1h30m" is read back as "1h30m0s".
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				o, oldErr := time.ParseDuration(old)
+				n, newErr := time.ParseDuration(new)
+				return oldErr == nil && newErr == nil && o == n
 			},
-			"upc": {
-				Type:     schema.TypeString,
-				Optional: true,
+		},
+		"sku": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"stockable": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"team_member_ids": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
 		},
-		Create: resourceSquareCatalogItemVariationCreate,
-		Read:   resourceSquareCatalogItemVariationRead,
-		Update: resourceSquareCatalogItemVariationUpdate,
-		Delete: resourceSquareCatalogItemVariationDelete,
+		"track_inventory": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"upc": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
 	}
 }
 
+func resourceSquareCatalogItemVariationCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateCatalogObjectLocations(d, meta); err != nil {
+		return err
+	}
+
+	return validateCatalogItemVariation(d, "", func() (string, error) {
+		return catalogItemProductType(d, meta)
+	}, meta)
+}
+
+// Returns the product type of the item a variation belongs to, or "" if the item has yet to be
+// created.
+func catalogItemProductType(d *schema.ResourceDiff, meta interface{}) (string, error) {
+	if !d.NewValueKnown("item_id") {
+		return "", nil
+	}
+
+	id := d.Get("item_id").(string)
+	item, err := meta.(client.SquareAPI).RetrieveCatalogObject(id)
+	if errors.Is(err, client.ErrNotFound) {
+		return "", fmt.Errorf("item %s does not exist", id)
+	} else if err != nil {
+		return "", err
+	}
+
+	if item.ItemData == nil {
+		return "", fmt.Errorf("%s is not an item", id)
+	}
+
+	return item.ItemData.ProductType, nil
+}
+
+// Validates the attributes of an item variation found under prefix, e.g. "variation.0.". A variable
+// price is entered at the time of sale, so it cannot be configured, and neither can a no-show fee
+// in its currency. A fixed price needs a currency, and a fixed price per measurement unit must not
+// be zero. A variation nobody can sell or stock is rejected by Square, an alert threshold needs
+// low quantity alerts, and a no-show fee is charged in the variation's currency. Service
+// attributes need the product type of the variation's item, which productType returns, or "" if it
+// is not known yet.
+func validateCatalogItemVariation(d *schema.ResourceDiff, prefix string, productType func() (string, error), meta interface{}) error {
+	price, currency := d.Get(prefix+"price").(int), d.Get(prefix+"currency").(string)
+	switch d.Get(prefix + "pricing_type").(string) {
+	case PricingTypeVariable:
+		if price != 0 || currency != "" {
+			return fmt.Errorf("price and currency cannot be set with %s", PricingTypeVariable)
+		}
+		if d.Get(prefix+"no_show_fee").(int) != 0 {
+			return fmt.Errorf("no_show_fee cannot be set with %s", PricingTypeVariable)
+		}
+	case PricingTypeFixed:
+		if currency == "" && d.NewValueKnown(prefix+"currency") {
+			return fmt.Errorf("currency is required with %s", PricingTypeFixed)
+		}
+		if price == 0 && d.Get(prefix+"measurement_unit_id").(string) != "" {
+			return fmt.Errorf("price per measurement unit is required with %s", PricingTypeFixed)
+		}
+	}
+
+	if !d.Get(prefix+"sellable").(bool) && !d.Get(prefix+"stockable").(bool) {
+		return fmt.Errorf("sellable and stockable cannot both be false")
+	}
+
+	if d.Get(prefix+"inventory_alert_threshold").(int) != 0 && d.Get(prefix+"inventory_alert_type").(string) != InventoryAlertTypeLowQuantity {
+		return fmt.Errorf("inventory_alert_threshold needs inventory_alert_type %s", InventoryAlertTypeLowQuantity)
+	}
+
+	if d.Get(prefix+"no_show_fee").(int) != 0 && d.Get(prefix+"currency").(string) == "" && d.NewValueKnown(prefix+"currency") {
+		return fmt.Errorf("no_show_fee needs the variation's currency")
+	}
+
+	if attr := configuredServiceAttribute(d, prefix); attr != "" {
+		itemType, err := productType()
+		if err != nil {
+			return err
+		}
+		if itemType != "" && itemType != ProductTypeAppointmentsService {
+			return fmt.Errorf("%s can only be set on a variation of an %s item, not %s", attr, ProductTypeAppointmentsService, itemType)
+		}
+	}
+
+	if d.NewValueKnown(prefix + "location_override.#") {
+		return validateItemVariationLocationOverrides(d, prefix, meta)
+	}
+
+	return nil
+}
+
+// Returns the first service attribute configured under prefix, or "" if there are none. Setting
+// available_for_booking to false is not configuring it, as false is what any variation has.
+func configuredServiceAttribute(d *schema.ResourceDiff, prefix string) string {
+	for _, attr := range catalogItemVariationServiceAttributes {
+		switch value := d.Get(prefix + attr).(type) {
+		case bool:
+			if value {
+				return attr
+			}
+		default:
+			if _, ok := d.GetOk(prefix + attr); ok {
+				return attr
+			}
+		}
+	}
+
+	return ""
+}
+
+// Each location can be overridden once. An overridden price is in the variation's currency and
+// needs fixed pricing, and an alert threshold only means something for low quantity alerts.
+func validateItemVariationLocationOverrides(d *schema.ResourceDiff, prefix string, meta interface{}) error {
+	// Overrides are hashed by location, so the set holds one override per location however many
+	// are configured.
+	overrides := d.Get(prefix + "location_override").(*schema.Set)
+	if d.Get(prefix+"location_override.#").(int) > overrides.Len() {
+		return fmt.Errorf("location_override: each location can only be overridden once")
+	}
+
+	currency := d.Get(prefix + "currency").(string)
+	ids := []string{}
+	for _, raw := range overrides.List() {
+		override := raw.(map[string]interface{})
+
+		id := override["location_id"].(string)
+		if id == "" {
+			continue
+		}
+		ids = append(ids, id)
+
+		switch override["pricing_type"].(string) {
+		case PricingTypeFixed:
+			if currency == "" && d.NewValueKnown(prefix+"currency") {
+				return fmt.Errorf("location_override for %s: %s needs the variation's currency", id, PricingTypeFixed)
+			}
+		default:
+			if override["price"].(int) != 0 {
+				return fmt.Errorf("location_override for %s: price can only be set with %s", id, PricingTypeFixed)
+			}
+		}
+
+		if override["inventory_alert_threshold"].(int) != 0 && override["inventory_alert_type"].(string) != InventoryAlertTypeLowQuantity {
+			return fmt.Errorf("location_override for %s: inventory_alert_threshold needs inventory_alert_type %s", id, InventoryAlertTypeLowQuantity)
+		}
+	}
+	sort.Strings(ids)
+
+	return validateLocationsExist(meta, ids)
+}
+
+// Hashes a location override by its location, so that changing an override updates it in place.
+func hashItemVariationLocationOverride(v interface{}) int {
+	return hashcode.String(v.(map[string]interface{})["location_id"].(string))
+}
+
 func resourceSquareCatalogItemVariationCreate(d *schema.ResourceData, meta interface{}) error {
-	created, err := meta.(client.SquareAPI).UpsertCatalogObject(&squaremodel.CatalogObject{
-		ID:                newTempID(),
-		Type:              strPtr(ItemVariationObjectType),
-		ItemVariationData: expandCatalogItemVariation(d),
+	obj := expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+		ID:      newCatalogObjectID(d),
+		Type:    strPtr(ItemVariationObjectType),
+		ImageID: d.Get("image_id").(string),
 	})
+	expandCatalogItemVariation(d, "", d.Get("item_id").(string), obj)
+
+	created, err := meta.(client.SquareAPI).UpsertCatalogObject(obj)
 	if err != nil {
-		return err
+		return attributeErrors(err, catalogItemVariationFields)
 	}
 
 	d.SetId(*created.ID)
@@ -85,76 +405,236 @@ func resourceSquareCatalogItemVariationCreate(d *schema.ResourceData, meta inter
 
 func resourceSquareCatalogItemVariationRead(d *schema.ResourceData, meta interface{}) error {
 	obj, err := meta.(client.SquareAPI).RetrieveCatalogObject(d.Id())
-	if err != nil {
+	if errors.Is(err, client.ErrNotFound) {
+		log.Printf("[WARN] catalog item variation %s no longer exists, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	} else if err != nil {
 		return err
 	}
 
-	return flattenCatalogItemVariation(obj.ItemVariationData, d)
+	d.Set("image_id", obj.ImageID)
+	d.Set("version", obj.Version)
+	flattenCatalogObjectLocations(obj, d)
+
+	return flattenCatalogItemVariation(obj, d)
 }
 
 func resourceSquareCatalogItemVariationUpdate(d *schema.ResourceData, meta interface{}) error {
-	if d.HasChange("item_id") ||
+	if d.HasChange("absent_at_location_ids") ||
+		d.HasChange("available_for_booking") ||
+		d.HasChange("image_id") ||
+		d.HasChange("inventory_alert_threshold") ||
+		d.HasChange("inventory_alert_type") ||
+		d.HasChange("item_id") ||
+		d.HasChange("item_option_values") ||
+		d.HasChange("location_override") ||
+		d.HasChange("measurement_unit_id") ||
 		d.HasChange("name") ||
+		d.HasChange("no_show_fee") ||
+		d.HasChange("present_at_all_locations") ||
+		d.HasChange("present_at_location_ids") ||
 		d.HasChange("pricing_type") ||
 		d.HasChange("price") ||
 		d.HasChange("currency") ||
+		d.HasChange("sellable") ||
+		d.HasChange("service_duration") ||
 		d.HasChange("sku") ||
+		d.HasChange("stockable") ||
+		d.HasChange("team_member_ids") ||
+		d.HasChange("track_inventory") ||
 		d.HasChange("upc") {
 
-		client := meta.(client.SquareAPI)
-		obj, err := client.RetrieveCatalogObject(d.Id())
-		if err != nil {
-			return err
-		}
+		obj := expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+			ID:      strPtr(d.Id()),
+			Type:    strPtr(ItemVariationObjectType),
+			ImageID: d.Get("image_id").(string),
+			Version: int64(d.Get("version").(int)),
+		})
+		expandCatalogItemVariation(d, "", d.Get("item_id").(string), obj)
 
-		if _, err := client.UpsertCatalogObject(&squaremodel.CatalogObject{
-			ID:                strPtr(*obj.ID),
-			Type:              strPtr(ItemVariationObjectType),
-			Version:           obj.Version,
-			ItemVariationData: expandCatalogItemVariation(d),
-		}); err != nil {
-			return err
+		if _, err := meta.(client.SquareAPI).UpsertCatalogObject(obj); err != nil {
+			return attributeErrors(err, catalogItemVariationFields)
 		}
 	}
 
-	return nil
+	return resourceSquareCatalogItemVariationRead(d, meta)
 }
 
 func resourceSquareCatalogItemVariationDelete(d *schema.ResourceData, meta interface{}) error {
 	_, err := meta.(client.SquareAPI).DeleteCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		return nil
+	}
+
 	return err
 }
 
-func expandCatalogItemVariation(d *schema.ResourceData) *squaremodel.CatalogItemVariation {
+// Expands the attributes of an item variation found under prefix, e.g. "variation.0.", into the
+// item variation data of a catalog object, making it a variation of the specified item.
+func expandCatalogItemVariation(d *schema.ResourceData, prefix, itemID string, obj *client.CatalogObject) {
 	itemVariation := &squaremodel.CatalogItemVariation{
-		ItemID:      d.Get("item_id").(string),
-		Name:        d.Get("name").(string),
-		PricingType: d.Get("pricing_type").(string),
-		Sku:         d.Get("sku").(string),
-		Upc:         d.Get("upc").(string),
+		InventoryAlertThreshold: int64(d.Get(prefix + "inventory_alert_threshold").(int)),
+		InventoryAlertType:      d.Get(prefix + "inventory_alert_type").(string),
+		ItemID:                  itemID,
+		MeasurementUnitID:       d.Get(prefix + "measurement_unit_id").(string),
+		Name:                    d.Get(prefix + "name").(string),
+		PricingType:             d.Get(prefix + "pricing_type").(string),
+		Sku:                     d.Get(prefix + "sku").(string),
+		TrackInventory:          d.Get(prefix + "track_inventory").(bool),
+		Upc:                     d.Get(prefix + "upc").(string),
+	}
+
+	if duration, err := time.ParseDuration(d.Get(prefix + "service_duration").(string)); err == nil {
+		itemVariation.ServiceDuration = int64(duration / time.Millisecond)
+	}
+
+	obj.SetField("item_variation_data.sellable", d.Get(prefix+"sellable").(bool))
+	obj.SetField("item_variation_data.stockable", d.Get(prefix+"stockable").(bool))
+	if available, ok := d.GetOk(prefix + "available_for_booking"); ok {
+		obj.SetField("item_variation_data.available_for_booking", available.(bool))
+	}
+	if fee := d.Get(prefix + "no_show_fee").(int); fee != 0 {
+		obj.SetField("item_variation_data.no_show_fee", map[string]interface{}{
+			"amount":   fee,
+			"currency": d.Get(prefix + "currency").(string),
+		})
+	}
+	if teamMemberIDs := d.Get(prefix + "team_member_ids").(*schema.Set); teamMemberIDs.Len() > 0 {
+		obj.SetField("item_variation_data.team_member_ids", sortedStrings(teamMemberIDs))
+	}
+
+	itemVariation.ItemOptionValues = []*squaremodel.CatalogItemOptionValueForItemVariation{}
+	for _, raw := range d.Get(prefix + "item_option_values").([]interface{}) {
+		value := raw.(map[string]interface{})
+		itemVariation.ItemOptionValues = append(itemVariation.ItemOptionValues, &squaremodel.CatalogItemOptionValueForItemVariation{
+			ItemOptionID:      value["item_option_id"].(string),
+			ItemOptionValueID: value["item_option_value_id"].(string),
+		})
+	}
+
+	itemVariation.LocationOverrides = []*squaremodel.ItemVariationLocationOverrides{}
+	for _, raw := range d.Get(prefix + "location_override").(*schema.Set).List() {
+		override := raw.(map[string]interface{})
+		locationOverride := &squaremodel.ItemVariationLocationOverrides{
+			InventoryAlertThreshold: int64(override["inventory_alert_threshold"].(int)),
+			InventoryAlertType:      override["inventory_alert_type"].(string),
+			LocationID:              override["location_id"].(string),
+			PricingType:             override["pricing_type"].(string),
+			TrackInventory:          override["track_inventory"].(bool),
+		}
+
+		if locationOverride.PricingType == PricingTypeFixed {
+			locationOverride.PriceMoney = &squaremodel.Money{
+				Amount:   int64(override["price"].(int)),
+				Currency: d.Get(prefix + "currency").(string),
+			}
+		}
+
+		itemVariation.LocationOverrides = append(itemVariation.LocationOverrides, locationOverride)
 	}
 
 	if itemVariation.PricingType == PricingTypeFixed {
 		itemVariation.PriceMoney = &squaremodel.Money{
-			Amount:   int64(d.Get("price").(int)),
-			Currency: d.Get("currency").(string),
+			Amount:   int64(d.Get(prefix + "price").(int)),
+			Currency: d.Get(prefix + "currency").(string),
 		}
 	}
 
-	return itemVariation
+	obj.ItemVariationData = itemVariation
 }
 
-func flattenCatalogItemVariation(itemVariation *squaremodel.CatalogItemVariation, d *schema.ResourceData) error {
-	d.Set("item_id", itemVariation.ItemID)
-	d.Set("name", itemVariation.Name)
-	d.Set("pricing_type", itemVariation.PricingType)
-	d.Set("sku", itemVariation.Sku)
-	d.Set("upc", itemVariation.Upc)
-
-	if itemVariation.PricingType == PricingTypeFixed {
-		d.Set("price", itemVariation.PriceMoney.Amount)
-		d.Set("currency", itemVariation.PriceMoney.Currency)
+func flattenCatalogItemVariation(obj *client.CatalogObject, d *schema.ResourceData) error {
+	d.Set("item_id", obj.ItemVariationData.ItemID)
+	for attr, value := range catalogItemVariationAttributes(obj) {
+		d.Set(attr, value)
 	}
 
 	return nil
 }
+
+// Returns the attributes in catalogItemVariationSchema describing an item variation.
+func catalogItemVariationAttributes(obj *client.CatalogObject) map[string]interface{} {
+	itemVariation := obj.ItemVariationData
+	field := func(name string) interface{} {
+		value, _ := obj.Field("item_variation_data." + name)
+		return value
+	}
+
+	attrs := map[string]interface{}{
+		"inventory_alert_threshold": int(itemVariation.InventoryAlertThreshold),
+		"inventory_alert_type":      inventoryAlertType(itemVariation.InventoryAlertType),
+		"measurement_unit_id":       itemVariation.MeasurementUnitID,
+		"name":                      itemVariation.Name,
+		"pricing_type":              itemVariation.PricingType,
+		"sku":                       itemVariation.Sku,
+		"track_inventory":           itemVariation.TrackInventory,
+		"upc":                       itemVariation.Upc,
+	}
+
+	optionValues := []interface{}{}
+	for _, value := range itemVariation.ItemOptionValues {
+		optionValues = append(optionValues, map[string]interface{}{
+			"item_option_id":       value.ItemOptionID,
+			"item_option_value_id": value.ItemOptionValueID,
+		})
+	}
+	attrs["item_option_values"] = optionValues
+
+	overrides := []interface{}{}
+	for i, override := range itemVariation.LocationOverrides {
+		price := int64(0)
+		if override.PricingType == PricingTypeFixed && override.PriceMoney != nil {
+			price = override.PriceMoney.Amount
+		}
+
+		soldOut, _ := field(fmt.Sprintf("location_overrides.%d.sold_out", i)).(bool)
+		overrides = append(overrides, map[string]interface{}{
+			"inventory_alert_threshold": int(override.InventoryAlertThreshold),
+			"inventory_alert_type":      inventoryAlertType(override.InventoryAlertType),
+			"location_id":               override.LocationID,
+			"price":                     int(price),
+			"pricing_type":              override.PricingType,
+			"sold_out":                  soldOut,
+			"track_inventory":           override.TrackInventory,
+		})
+	}
+	attrs["location_override"] = overrides
+
+	// Square treats a variation it has no setting for as sellable and stockable.
+	for _, attr := range []string{"sellable", "stockable"} {
+		value, ok := field(attr).(bool)
+		attrs[attr] = value || !ok
+	}
+
+	attrs["service_duration"] = ""
+	if itemVariation.ServiceDuration > 0 {
+		attrs["service_duration"] = (time.Duration(itemVariation.ServiceDuration) * time.Millisecond).String()
+	}
+
+	attrs["available_for_booking"], _ = field("available_for_booking").(bool)
+	teamMemberIDs, _ := field("team_member_ids").([]interface{})
+	attrs["team_member_ids"] = teamMemberIDs
+	noShowFee, _ := field("no_show_fee").(map[string]interface{})
+	amount, _ := noShowFee["amount"].(float64)
+	attrs["no_show_fee"] = int(amount)
+
+	if itemVariation.PricingType == PricingTypeFixed && itemVariation.PriceMoney != nil {
+		attrs["price"] = int(itemVariation.PriceMoney.Amount)
+		attrs["currency"] = itemVariation.PriceMoney.Currency
+	} else {
+		attrs["price"] = 0
+		attrs["currency"] = ""
+	}
+
+	return attrs
+}
+
+// Square leaves out the alert type of a variation or override that has no alerts.
+func inventoryAlertType(alertType string) string {
+	if alertType == "" {
+		return InventoryAlertTypeNone
+	}
+
+	return alertType
+}
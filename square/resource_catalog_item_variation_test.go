@@ -0,0 +1,599 @@
+package square
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSquareCatalogItemVariation_update(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_item_variation"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogItemVariationConfigFixed("Large", 3500, "USD", "TS-L", "012345678905"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_item_variation.test"),
+					testAccCheckResourceID("square_catalog_item_variation.test", &id, false),
+					resource.TestCheckResourceAttrPair("square_catalog_item_variation.test", "item_id", "square_catalog_item.test", "id"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "name", "Large"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "pricing_type", "FIXED_PRICING"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "price", "3500"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "currency", "USD"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "sku", "TS-L"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "upc", "012345678905"),
+					resource.TestCheckResourceAttrSet("square_catalog_item_variation.test", "version"),
+				),
+			},
+			{
+				Config: testAccSquareCatalogItemVariationConfigFixed("Extra Large (XL)", 3900, "CAD", "TS-XL", "012345678912"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_item_variation.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "name", "Extra Large (XL)"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "price", "3900"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "currency", "CAD"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "sku", "TS-XL"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "upc", "012345678912"),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_item_variation.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogItemVariation_moveToAnotherItem(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_item_variation"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogItemVariationConfigVariable("test", "Market Price"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_item_variation.test", &id, false),
+					testAccCheckCatalogItemVariations("square_catalog_item.test", "Market Price"),
+				),
+			},
+			{
+				Config: testAccSquareCatalogItemVariationConfigVariable("other", "Market Price"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_item_variation.test", &id, false),
+					resource.TestCheckResourceAttrPair("square_catalog_item_variation.test", "item_id", "square_catalog_item.other", "id"),
+					testAccCheckCatalogItemVariations("square_catalog_item.test"),
+					testAccCheckCatalogItemVariations("square_catalog_item.other", "Market Price"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogItemVariation_itemOptionValues(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_item_variation"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogItemVariationConfigItemOptionValues(1, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_item_variation.test"),
+					resource.TestCheckResourceAttr("square_catalog_item.test", "item_options.#", "2"),
+					resource.TestCheckResourceAttrPair("square_catalog_item.test", "item_options.0", "square_catalog_item_option.size", "id"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "item_option_values.#", "2"),
+					resource.TestCheckResourceAttrPair("square_catalog_item_variation.test", "item_option_values.0.item_option_id", "square_catalog_item_option.size", "id"),
+					resource.TestCheckResourceAttrPair("square_catalog_item_variation.test", "item_option_values.0.item_option_value_id", "square_catalog_item_option.size", "values.1.id"),
+					resource.TestCheckResourceAttrPair("square_catalog_item_variation.test", "item_option_values.1.item_option_value_id", "square_catalog_item_option.color", "values.0.id"),
+				),
+			},
+			{
+				Config: testAccSquareCatalogItemVariationConfigItemOptionValues(0, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("square_catalog_item_variation.test", "item_option_values.0.item_option_value_id", "square_catalog_item_option.size", "values.0.id"),
+					resource.TestCheckResourceAttrPair("square_catalog_item_variation.test", "item_option_values.1.item_option_value_id", "square_catalog_item_option.color", "values.1.id"),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_item_variation.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogItemVariation_measurementUnit(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_item_variation"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogItemVariationConfigPerUnit(899, true, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_item_variation.test"),
+					resource.TestCheckResourceAttrPair("square_catalog_item_variation.test", "measurement_unit_id", "square_catalog_measurement_unit.pound", "id"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "price", "899"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "sellable", "true"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "stockable", "false"),
+				),
+			},
+			{
+				Config: testAccSquareCatalogItemVariationConfigPerUnit(949, false, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_item_variation.test"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "price", "949"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "sellable", "false"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "stockable", "true"),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_item_variation.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+			{
+				Config: testAccSquareCatalogItemVariationConfigFixed("Large", 3500, "USD", "TS-L", "012345678905"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "measurement_unit_id", ""),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "sellable", "true"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "stockable", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogItemVariation_inventory(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_item_variation"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogItemVariationConfigInventory(true, "LOW_QUANTITY", 3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_item_variation.test"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "track_inventory", "true"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "inventory_alert_type", "LOW_QUANTITY"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "inventory_alert_threshold", "3"),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_item_variation.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+			{
+				Config: testAccSquareCatalogItemVariationConfigInventory(false, "NONE", 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "track_inventory", "false"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "inventory_alert_type", "NONE"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "inventory_alert_threshold", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogItemVariation_service(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_item_variation"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogItemVariationConfigService("APPOINTMENTS_SERVICE", "45m", true, 1000),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_item_variation.test"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "service_duration", "45m0s"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "available_for_booking", "true"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "no_show_fee", "1000"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "team_member_ids.#", "2"),
+				),
+			},
+			{
+				Config: testAccSquareCatalogItemVariationConfigService("APPOINTMENTS_SERVICE", "1h30m", true, 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "service_duration", "1h30m0s"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "no_show_fee", "0"),
+				),
+			},
+			{
+				// An equivalent duration is not a change.
+				Config:   testAccSquareCatalogItemVariationConfigService("APPOINTMENTS_SERVICE", "90m", true, 0),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccSquareCatalogItemVariationConfigService("APPOINTMENTS_SERVICE", "90m", false, 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "available_for_booking", "false"),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_item_variation.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogItemVariation_locationOverrides(t *testing.T) {
+	airport, mall := testAccLocationIDs(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_item_variation"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogItemVariationConfigLocationOverrides(
+					testAccSquareCatalogItemVariationLocationOverride(airport, 450),
+					testAccSquareCatalogItemVariationInventoryOverride(mall, 5),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_item_variation.test"),
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "location_override.#", "2"),
+				),
+			},
+			{
+				// Reordering the blocks changes nothing.
+				Config: testAccSquareCatalogItemVariationConfigLocationOverrides(
+					testAccSquareCatalogItemVariationInventoryOverride(mall, 5),
+					testAccSquareCatalogItemVariationLocationOverride(airport, 450),
+				),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccSquareCatalogItemVariationConfigLocationOverrides(
+					testAccSquareCatalogItemVariationLocationOverride(airport, 500),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "location_override.#", "1"),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_item_variation.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+			{
+				Config: testAccSquareCatalogItemVariationConfigLocationOverrides(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_catalog_item_variation.test", "location_override.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogItemVariation_duplicateLocationOverrides(t *testing.T) {
+	airport, mall := testAccLocationIDs(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_item_variation"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogItemVariationConfigLocationOverrides(
+					testAccSquareCatalogItemVariationLocationOverride(airport, 450),
+					testAccSquareCatalogItemVariationInventoryOverride(airport, 5),
+				),
+				ExpectError: regexp.MustCompile("each location can only be overridden once"),
+			},
+			{
+				Config: testAccSquareCatalogItemVariationConfigLocationOverrides(
+					testAccSquareCatalogItemVariationLocationOverride(airport, 450),
+					testAccSquareCatalogItemVariationInventoryOverride(mall, 5),
+				),
+			},
+			{
+				// Overrides for the same location are caught when updating too.
+				Config: testAccSquareCatalogItemVariationConfigLocationOverrides(
+					testAccSquareCatalogItemVariationLocationOverride(airport, 450),
+					testAccSquareCatalogItemVariationInventoryOverride(airport, 5),
+				),
+				ExpectError: regexp.MustCompile("each location can only be overridden once"),
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogItemVariation_invalid(t *testing.T) {
+	airport, _ := testAccLocationIDs(t)
+
+	testAccRunRejectedConfigs(t, []testAccRejectedConfig{
+		{
+			name: "priced variable pricing",
+			config: `
+resource "square_catalog_item_variation" "test" {
+  item_id      = "ITEM"
+  name         = "Market Price"
+  pricing_type = "VARIABLE_PRICING"
+  price        = 100
+  currency     = "USD"
+}
+`,
+			err: "price and currency cannot be set with VARIABLE_PRICING",
+		},
+		{
+			name: "fixed pricing without currency",
+			config: `
+resource "square_catalog_item_variation" "test" {
+  item_id      = "ITEM"
+  name         = "Large"
+  pricing_type = "FIXED_PRICING"
+  price        = 100
+}
+`,
+			err: "currency is required with FIXED_PRICING",
+		},
+		{
+			name: "fixed pricing per unit without price",
+			config: `
+resource "square_catalog_item_variation" "test" {
+  item_id             = "ITEM"
+  name                = "By the pound"
+  pricing_type        = "FIXED_PRICING"
+  currency            = "USD"
+  measurement_unit_id = "UNIT"
+}
+`,
+			err: "price per measurement unit is required with FIXED_PRICING",
+		},
+		{
+			name: "neither sellable nor stockable",
+			config: `
+resource "square_catalog_item_variation" "test" {
+  item_id      = "ITEM"
+  name         = "Nothing"
+  pricing_type = "VARIABLE_PRICING"
+  sellable     = false
+  stockable    = false
+}
+`,
+			err: "sellable and stockable cannot both be false",
+		},
+		{
+			name:   "alert threshold without low quantity alerts",
+			config: testAccSquareCatalogItemVariationConfigInventory(true, "NONE", 3),
+			err:    "inventory_alert_threshold needs inventory_alert_type LOW_QUANTITY",
+		},
+		{
+			name:   "unparseable service duration",
+			config: testAccSquareCatalogItemVariationConfigService("APPOINTMENTS_SERVICE", "45 minutes", true, 0),
+			err:    "service_duration '45 minutes' is not a positive duration",
+		},
+		{
+			name:   "service attributes on a regular item",
+			config: testAccSquareCatalogItemVariationConfigService("REGULAR", "45m", true, 0),
+			err:    "available_for_booking can only be set on a variation of an APPOINTMENTS_SERVICE item, not REGULAR",
+		},
+		{
+			name: "no-show fee with variable pricing",
+			config: `
+resource "square_catalog_item" "test" {
+  name         = "Haircut"
+  product_type = "APPOINTMENTS_SERVICE"
+}
+
+resource "square_catalog_item_variation" "test" {
+  item_id      = square_catalog_item.test.id
+  name         = "Consultation"
+  pricing_type = "VARIABLE_PRICING"
+  no_show_fee  = 1000
+}
+`,
+			err: "no_show_fee cannot be set with VARIABLE_PRICING",
+		},
+		{
+			name: "override at an unknown location",
+			config: testAccSquareCatalogItemVariationConfigLocationOverrides(
+				testAccSquareCatalogItemVariationInventoryOverride("NOSUCHLOCATION", 5),
+			),
+			err: "locations do not exist: NOSUCHLOCATION",
+		},
+		{
+			name: "override price with variable pricing",
+			config: testAccSquareCatalogItemVariationConfigLocationOverrides(fmt.Sprintf(`
+  location_override {
+    location_id  = %q
+    pricing_type = "VARIABLE_PRICING"
+    price        = 450
+  }
+`, airport)),
+			err: "price can only be set with FIXED_PRICING",
+		},
+		{
+			name: "override alert threshold without low quantity alerts",
+			config: testAccSquareCatalogItemVariationConfigLocationOverrides(fmt.Sprintf(`
+  location_override {
+    location_id               = %q
+    inventory_alert_threshold = 5
+  }
+`, airport)),
+			err: "inventory_alert_threshold needs inventory_alert_type LOW_QUANTITY",
+		},
+	})
+}
+
+func testAccSquareCatalogItemVariationConfigFixed(name string, price int, currency, sku, upc string) string {
+	return testAccCatalogItemConfig("test", "T-shirt") + fmt.Sprintf(`
+resource "square_catalog_item_variation" "test" {
+  item_id      = square_catalog_item.test.id
+  name         = %q
+  pricing_type = "FIXED_PRICING"
+  price        = %d
+  currency     = %q
+  sku          = %q
+  upc          = %q
+}
+`, name, price, currency, sku, upc)
+}
+
+// Configures a variable priced variation of the item with the specified resource name, which is
+// either test or other.
+func testAccSquareCatalogItemVariationConfigVariable(item, name string) string {
+	return testAccCatalogItemConfig("test", "T-shirt") + testAccCatalogItemConfig("other", "Fish") + fmt.Sprintf(`
+resource "square_catalog_item_variation" "test" {
+  item_id      = square_catalog_item.%s.id
+  name         = %q
+  pricing_type = "VARIABLE_PRICING"
+}
+`, item, name)
+}
+
+func testAccSquareCatalogItemVariationConfigPerUnit(price int, sellable, stockable bool) string {
+	return testAccSquareCatalogMeasurementUnitConfigPound("pound") + testAccCatalogItemConfig("test", "Smoked turkey") + fmt.Sprintf(`
+resource "square_catalog_item_variation" "test" {
+  item_id             = square_catalog_item.test.id
+  name                = "By the pound"
+  pricing_type        = "FIXED_PRICING"
+  price               = %d
+  currency            = "USD"
+  measurement_unit_id = square_catalog_measurement_unit.pound.id
+  sellable            = %t
+  stockable           = %t
+}
+`, price, sellable, stockable)
+}
+
+func testAccSquareCatalogItemVariationConfigItemOptionValues(size, color int) string {
+	return fmt.Sprintf(`
+resource "square_catalog_item_option" "size" {
+  name = "Size"
+
+  values {
+    name = "L"
+  }
+
+  values {
+    name = "XL"
+  }
+}
+
+resource "square_catalog_item_option" "color" {
+  name        = "Color"
+  show_colors = true
+
+  values {
+    name  = "Red"
+    color = "FF0000"
+  }
+
+  values {
+    name  = "Blue"
+    color = "0000FF"
+  }
+}
+
+resource "square_catalog_item" "test" {
+  name         = "T-shirt"
+  item_options = [square_catalog_item_option.size.id, square_catalog_item_option.color.id]
+}
+
+resource "square_catalog_item_variation" "test" {
+  item_id      = square_catalog_item.test.id
+  name         = "T-shirt"
+  pricing_type = "VARIABLE_PRICING"
+
+  item_option_values {
+    item_option_id       = square_catalog_item_option.size.id
+    item_option_value_id = square_catalog_item_option.size.values[%d].id
+  }
+
+  item_option_values {
+    item_option_id       = square_catalog_item_option.color.id
+    item_option_value_id = square_catalog_item_option.color.values[%d].id
+  }
+}
+`, size, color)
+}
+
+func testAccSquareCatalogItemVariationConfigLocationOverrides(overrides ...string) string {
+	return testAccCatalogItemConfig("test", "Neck pillow") + fmt.Sprintf(`
+resource "square_catalog_item_variation" "test" {
+  item_id      = square_catalog_item.test.id
+  name         = "Regular"
+  pricing_type = "FIXED_PRICING"
+  price        = 300
+  currency     = "USD"
+%s
+}
+`, strings.Join(overrides, ""))
+}
+
+func testAccSquareCatalogItemVariationLocationOverride(locationID string, price int) string {
+	return fmt.Sprintf(`
+  location_override {
+    location_id  = %q
+    pricing_type = "FIXED_PRICING"
+    price        = %d
+  }
+`, locationID, price)
+}
+
+func testAccSquareCatalogItemVariationInventoryOverride(locationID string, threshold int) string {
+	return fmt.Sprintf(`
+  location_override {
+    location_id               = %q
+    track_inventory           = true
+    inventory_alert_type      = "LOW_QUANTITY"
+    inventory_alert_threshold = %d
+  }
+`, locationID, threshold)
+}
+
+func testAccSquareCatalogItemVariationConfigInventory(track bool, alertType string, threshold int) string {
+	return testAccCatalogItemConfig("test", "Travel mug") + fmt.Sprintf(`
+resource "square_catalog_item_variation" "test" {
+  item_id                   = square_catalog_item.test.id
+  name                      = "Regular"
+  pricing_type              = "FIXED_PRICING"
+  price                     = 1500
+  currency                  = "USD"
+  track_inventory           = %t
+  inventory_alert_type      = %q
+  inventory_alert_threshold = %d
+}
+`, track, alertType, threshold)
+}
+
+func testAccSquareCatalogItemVariationConfigService(productType, duration string, availableForBooking bool, noShowFee int) string {
+	return fmt.Sprintf(`
+resource "square_catalog_item" "test" {
+  name         = "Haircut"
+  product_type = %q
+}
+
+resource "square_catalog_item_variation" "test" {
+  item_id               = square_catalog_item.test.id
+  name                  = "Standard"
+  pricing_type          = "FIXED_PRICING"
+  price                 = 4500
+  currency              = "USD"
+  service_duration      = %q
+  available_for_booking = %t
+  no_show_fee           = %d
+  team_member_ids       = ["TEAM_MEMBER1", "TEAM_MEMBER2"]
+}
+`, productType, duration, availableForBooking, noShowFee)
+}
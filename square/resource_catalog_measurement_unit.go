@@ -0,0 +1,301 @@
+package square
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	squaremodel "github.com/jefflinse/square-connect/models"
+	"github.com/jefflinse/terraform-provider-square/square/client"
+)
+
+const (
+	// CatalogMeasurementUnitMaxPrecision is the maximum number of decimal places of a quantity measured in a unit.
+	CatalogMeasurementUnitMaxPrecision = 5
+
+	// MeasurementUnitObjectType is the Square type for a catalog object describing a measurement unit.
+	MeasurementUnitObjectType = "MEASUREMENT_UNIT"
+
+	// MeasurementUnitTypeCustom designates a measurement unit defined by its name and abbreviation.
+	MeasurementUnitTypeCustom = "TYPE_CUSTOM"
+)
+
+// standardMeasurementUnits maps the attribute configuring each kind of standard unit to the
+// Square unit type it designates and the units of that kind.
+var standardMeasurementUnits = map[string]struct {
+	unitType string
+	units    []string
+}{
+	"area_unit": {"TYPE_AREA", []string{
+		"IMPERIAL_ACRE", "IMPERIAL_SQUARE_INCH", "IMPERIAL_SQUARE_FOOT", "IMPERIAL_SQUARE_YARD", "IMPERIAL_SQUARE_MILE",
+		"METRIC_SQUARE_CENTIMETER", "METRIC_SQUARE_METER", "METRIC_SQUARE_KILOMETER",
+	}},
+	"generic_unit": {"TYPE_GENERIC", []string{
+		"UNIT",
+	}},
+	"length_unit": {"TYPE_LENGTH", []string{
+		"IMPERIAL_INCH", "IMPERIAL_FOOT", "IMPERIAL_YARD", "IMPERIAL_MILE",
+		"METRIC_MILLIMETER", "METRIC_CENTIMETER", "METRIC_METER", "METRIC_KILOMETER",
+	}},
+	"volume_unit": {"TYPE_VOLUME", []string{
+		"GENERIC_FLUID_OUNCE", "GENERIC_SHOT", "GENERIC_CUP", "GENERIC_PINT", "GENERIC_QUART", "GENERIC_GALLON",
+		"IMPERIAL_CUBIC_INCH", "IMPERIAL_CUBIC_FOOT", "IMPERIAL_CUBIC_YARD",
+		"METRIC_MILLILITER", "METRIC_LITER",
+	}},
+	"weight_unit": {"TYPE_WEIGHT", []string{
+		"IMPERIAL_WEIGHT_OUNCE", "IMPERIAL_POUND", "IMPERIAL_STONE",
+		"METRIC_MILLIGRAM", "METRIC_GRAM", "METRIC_KILOGRAM",
+	}},
+}
+
+func resourceSquareCatalogMeasurementUnit() *schema.Resource {
+	return &schema.Resource{
+		Schema: withIdempotencyKey(withLocationAttributes(map[string]*schema.Schema{
+			"area_unit": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: measurementUnitConflicts("area_unit"),
+				ValidateFunc:  validation.StringInSlice(standardMeasurementUnits["area_unit"].units, false),
+			},
+			"custom_unit": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: measurementUnitConflicts("custom_unit"),
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"abbreviation": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"generic_unit": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: measurementUnitConflicts("generic_unit"),
+				ValidateFunc:  validation.StringInSlice(standardMeasurementUnits["generic_unit"].units, false),
+			},
+			"length_unit": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: measurementUnitConflicts("length_unit"),
+				ValidateFunc:  validation.StringInSlice(standardMeasurementUnits["length_unit"].units, false),
+			},
+			"precision": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntBetween(0, CatalogMeasurementUnitMaxPrecision),
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"volume_unit": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: measurementUnitConflicts("volume_unit"),
+				ValidateFunc:  validation.StringInSlice(standardMeasurementUnits["volume_unit"].units, false),
+			},
+			"weight_unit": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: measurementUnitConflicts("weight_unit"),
+				ValidateFunc:  validation.StringInSlice(standardMeasurementUnits["weight_unit"].units, false),
+			},
+		})),
+		Create:        resourceSquareCatalogMeasurementUnitCreate,
+		Read:          resourceSquareCatalogMeasurementUnitRead,
+		Update:        resourceSquareCatalogMeasurementUnitUpdate,
+		Delete:        resourceSquareCatalogMeasurementUnitDelete,
+		CustomizeDiff: resourceSquareCatalogMeasurementUnitCustomizeDiff,
+		Importer:      importCatalogObject(MeasurementUnitObjectType),
+	}
+}
+
+// Returns the unit attributes other than attr, all of which conflict with it.
+func measurementUnitConflicts(attr string) []string {
+	conflicts := []string{}
+	for other := range standardMeasurementUnits {
+		if other != attr {
+			conflicts = append(conflicts, other)
+		}
+	}
+	if attr != "custom_unit" {
+		conflicts = append(conflicts, "custom_unit")
+	}
+	sort.Strings(conflicts)
+
+	return conflicts
+}
+
+// A measurement unit is either a custom unit or one of the standard units, so exactly one unit
+// attribute must be set. The unit type follows from which one it is.
+func resourceSquareCatalogMeasurementUnitCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateCatalogObjectLocations(d, meta); err != nil {
+		return err
+	}
+
+	unitType := ""
+	if d.Get("custom_unit.#").(int) > 0 {
+		unitType = MeasurementUnitTypeCustom
+	}
+	for attr, kind := range standardMeasurementUnits {
+		if d.Get(attr).(string) != "" || !d.NewValueKnown(attr) {
+			unitType = kind.unitType
+		}
+	}
+
+	if unitType == "" {
+		attrs := []string{"custom_unit"}
+		for attr := range standardMeasurementUnits {
+			attrs = append(attrs, attr)
+		}
+		sort.Strings(attrs)
+		return fmt.Errorf("one of %s must be set", strings.Join(attrs, ", "))
+	}
+
+	if d.Get("type").(string) != unitType {
+		return d.SetNew("type", unitType)
+	}
+
+	return nil
+}
+
+func resourceSquareCatalogMeasurementUnitCreate(d *schema.ResourceData, meta interface{}) error {
+	created, err := meta.(client.SquareAPI).UpsertCatalogObject(expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+		ID:                  newCatalogObjectID(d),
+		Type:                strPtr(MeasurementUnitObjectType),
+		MeasurementUnitData: expandCatalogMeasurementUnit(d),
+	}))
+	if err != nil {
+		return attributeErrors(err, nil)
+	}
+
+	d.SetId(*created.ID)
+
+	return resourceSquareCatalogMeasurementUnitRead(d, meta)
+}
+
+func resourceSquareCatalogMeasurementUnitRead(d *schema.ResourceData, meta interface{}) error {
+	obj, err := meta.(client.SquareAPI).RetrieveCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		log.Printf("[WARN] catalog measurement unit %s no longer exists, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	d.Set("version", obj.Version)
+	flattenCatalogObjectLocations(obj, d)
+
+	return flattenCatalogMeasurementUnit(obj.MeasurementUnitData, d)
+}
+
+func resourceSquareCatalogMeasurementUnitUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("absent_at_location_ids") ||
+		d.HasChange("area_unit") ||
+		d.HasChange("custom_unit") ||
+		d.HasChange("generic_unit") ||
+		d.HasChange("length_unit") ||
+		d.HasChange("precision") ||
+		d.HasChange("present_at_all_locations") ||
+		d.HasChange("present_at_location_ids") ||
+		d.HasChange("volume_unit") ||
+		d.HasChange("weight_unit") {
+
+		if _, err := meta.(client.SquareAPI).UpsertCatalogObject(expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+			ID:                  strPtr(d.Id()),
+			Type:                strPtr(MeasurementUnitObjectType),
+			Version:             int64(d.Get("version").(int)),
+			MeasurementUnitData: expandCatalogMeasurementUnit(d),
+		})); err != nil {
+			return attributeErrors(err, nil)
+		}
+	}
+
+	return resourceSquareCatalogMeasurementUnitRead(d, meta)
+}
+
+func resourceSquareCatalogMeasurementUnitDelete(d *schema.ResourceData, meta interface{}) error {
+	_, err := meta.(client.SquareAPI).DeleteCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+func expandCatalogMeasurementUnit(d *schema.ResourceData) *squaremodel.CatalogMeasurementUnit {
+	unit := &squaremodel.MeasurementUnit{
+		AreaUnit:    d.Get("area_unit").(string),
+		GenericUnit: d.Get("generic_unit").(string),
+		LengthUnit:  d.Get("length_unit").(string),
+		VolumeUnit:  d.Get("volume_unit").(string),
+		WeightUnit:  d.Get("weight_unit").(string),
+	}
+
+	if custom := d.Get("custom_unit").([]interface{}); len(custom) > 0 && custom[0] != nil {
+		c := custom[0].(map[string]interface{})
+		unit.Type = MeasurementUnitTypeCustom
+		unit.CustomUnit = &squaremodel.MeasurementUnitCustom{
+			Abbreviation: strPtr(c["abbreviation"].(string)),
+			Name:         strPtr(c["name"].(string)),
+		}
+	}
+
+	for attr, kind := range standardMeasurementUnits {
+		if d.Get(attr).(string) != "" {
+			unit.Type = kind.unitType
+		}
+	}
+
+	return &squaremodel.CatalogMeasurementUnit{
+		MeasurementUnit: unit,
+		Precision:       int64(d.Get("precision").(int)),
+	}
+}
+
+func flattenCatalogMeasurementUnit(measurementUnit *squaremodel.CatalogMeasurementUnit, d *schema.ResourceData) error {
+	d.Set("precision", measurementUnit.Precision)
+
+	unit := measurementUnit.MeasurementUnit
+	if unit == nil {
+		unit = &squaremodel.MeasurementUnit{}
+	}
+
+	d.Set("area_unit", unit.AreaUnit)
+	d.Set("generic_unit", unit.GenericUnit)
+	d.Set("length_unit", unit.LengthUnit)
+	d.Set("type", unit.Type)
+	d.Set("volume_unit", unit.VolumeUnit)
+	d.Set("weight_unit", unit.WeightUnit)
+
+	if unit.CustomUnit != nil {
+		d.Set("custom_unit", []interface{}{
+			map[string]interface{}{
+				"abbreviation": client.StringValue(unit.CustomUnit.Abbreviation),
+				"name":         client.StringValue(unit.CustomUnit.Name),
+			},
+		})
+	} else {
+		d.Set("custom_unit", []interface{}{})
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package square
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSquareCatalogMeasurementUnit_changeUnit(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_measurement_unit"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogMeasurementUnitConfigPound("test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_measurement_unit.test"),
+					testAccCheckResourceID("square_catalog_measurement_unit.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_measurement_unit.test", "weight_unit", "IMPERIAL_POUND"),
+					resource.TestCheckResourceAttr("square_catalog_measurement_unit.test", "type", "TYPE_WEIGHT"),
+					resource.TestCheckResourceAttr("square_catalog_measurement_unit.test", "precision", "2"),
+					resource.TestCheckResourceAttr("square_catalog_measurement_unit.test", "custom_unit.#", "0"),
+					resource.TestCheckResourceAttrSet("square_catalog_measurement_unit.test", "version"),
+				),
+			},
+			{
+				Config: `
+resource "square_catalog_measurement_unit" "test" {
+  custom_unit {
+    name         = "Slice"
+    abbreviation = "sl"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_measurement_unit.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_measurement_unit.test", "weight_unit", ""),
+					resource.TestCheckResourceAttr("square_catalog_measurement_unit.test", "type", "TYPE_CUSTOM"),
+					resource.TestCheckResourceAttr("square_catalog_measurement_unit.test", "precision", "0"),
+					resource.TestCheckResourceAttr("square_catalog_measurement_unit.test", "custom_unit.#", "1"),
+					resource.TestCheckResourceAttr("square_catalog_measurement_unit.test", "custom_unit.0.name", "Slice"),
+					resource.TestCheckResourceAttr("square_catalog_measurement_unit.test", "custom_unit.0.abbreviation", "sl"),
+				),
+			},
+			{
+				Config: `
+resource "square_catalog_measurement_unit" "test" {
+  volume_unit = "METRIC_LITER"
+  precision   = 3
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_measurement_unit.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_measurement_unit.test", "volume_unit", "METRIC_LITER"),
+					resource.TestCheckResourceAttr("square_catalog_measurement_unit.test", "type", "TYPE_VOLUME"),
+					resource.TestCheckResourceAttr("square_catalog_measurement_unit.test", "precision", "3"),
+					resource.TestCheckResourceAttr("square_catalog_measurement_unit.test", "custom_unit.#", "0"),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_measurement_unit.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogMeasurementUnit_invalid(t *testing.T) {
+	testAccRunRejectedConfigs(t, []testAccRejectedConfig{
+		{
+			name: "no unit",
+			config: `
+resource "square_catalog_measurement_unit" "test" {
+  precision = 2
+}
+`,
+			err: "one of area_unit, custom_unit, generic_unit, length_unit, volume_unit, weight_unit must be set",
+		},
+		{
+			name: "two units",
+			config: `
+resource "square_catalog_measurement_unit" "test" {
+  weight_unit = "IMPERIAL_POUND"
+  volume_unit = "METRIC_LITER"
+}
+`,
+			err: "conflicts with",
+		},
+		{
+			name: "unit of another type",
+			config: `
+resource "square_catalog_measurement_unit" "test" {
+  weight_unit = "METRIC_LITER"
+}
+`,
+			err: "expected weight_unit to be one of",
+		},
+	})
+}
+
+func testAccSquareCatalogMeasurementUnitConfigPound(label string) string {
+	return fmt.Sprintf(`
+resource "square_catalog_measurement_unit" %q {
+  weight_unit = "IMPERIAL_POUND"
+  precision   = 2
+}
+`, label)
+}
@@ -0,0 +1,195 @@
+package square
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	squaremodel "github.com/jefflinse/square-connect/models"
+	"github.com/jefflinse/terraform-provider-square/square/client"
+)
+
+const (
+	// CatalogModifierNameMaxLength is the maximum length for a modifier's name.
+	CatalogModifierNameMaxLength = 255
+
+	// ModifierObjectType is the Square type for a catalog object describing a modifier.
+	ModifierObjectType = "MODIFIER"
+)
+
+// Maps Square modifier fields to the attributes they are configured by.
+var catalogModifierFields = map[string]string{
+	"price_money.amount":   "price",
+	"price_money.currency": "currency",
+}
+
+func resourceSquareCatalogModifier() *schema.Resource {
+	return &schema.Resource{
+		Schema: withIdempotencyKey(withLocationAttributes(map[string]*schema.Schema{
+			"currency": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"modifier_list_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(v interface{}, k string) (wrns []string, errs []error) {
+					val := v.(string)
+					if len(val) > CatalogModifierNameMaxLength {
+						errs = append(errs, fmt.Errorf("modifier name '%s' exceeds max length of %d", val, CatalogModifierNameMaxLength))
+					}
+					return
+				},
+			},
+			"on_by_default": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"ordinal": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"price": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		})),
+		Create:        resourceSquareCatalogModifierCreate,
+		Read:          resourceSquareCatalogModifierRead,
+		Update:        resourceSquareCatalogModifierUpdate,
+		Delete:        resourceSquareCatalogModifierDelete,
+		CustomizeDiff: resourceSquareCatalogModifierCustomizeDiff,
+		Importer:      importCatalogObject(ModifierObjectType),
+	}
+}
+
+// Validates the modifier's locations and that a price comes with the currency it is in.
+func resourceSquareCatalogModifierCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateCatalogObjectLocations(d, meta); err != nil {
+		return err
+	}
+
+	if d.Get("price").(int) != 0 && d.Get("currency").(string) == "" && d.NewValueKnown("currency") {
+		return fmt.Errorf("price needs a currency")
+	}
+
+	return nil
+}
+
+func resourceSquareCatalogModifierCreate(d *schema.ResourceData, meta interface{}) error {
+	obj := expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+		ID:   newCatalogObjectID(d),
+		Type: strPtr(ModifierObjectType),
+	})
+	expandCatalogModifier(d, obj)
+
+	created, err := meta.(client.SquareAPI).UpsertCatalogObject(obj)
+	if err != nil {
+		return attributeErrors(err, catalogModifierFields)
+	}
+
+	d.SetId(*created.ID)
+
+	return resourceSquareCatalogModifierRead(d, meta)
+}
+
+func resourceSquareCatalogModifierRead(d *schema.ResourceData, meta interface{}) error {
+	obj, err := meta.(client.SquareAPI).RetrieveCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		log.Printf("[WARN] catalog modifier %s no longer exists, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	d.Set("version", obj.Version)
+	flattenCatalogObjectLocations(obj, d)
+
+	return flattenCatalogModifier(obj, d)
+}
+
+func resourceSquareCatalogModifierUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("absent_at_location_ids") ||
+		d.HasChange("currency") ||
+		d.HasChange("name") ||
+		d.HasChange("on_by_default") ||
+		d.HasChange("ordinal") ||
+		d.HasChange("present_at_all_locations") ||
+		d.HasChange("present_at_location_ids") ||
+		d.HasChange("price") {
+
+		obj := expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+			ID:      strPtr(d.Id()),
+			Type:    strPtr(ModifierObjectType),
+			Version: int64(d.Get("version").(int)),
+		})
+		expandCatalogModifier(d, obj)
+
+		if _, err := meta.(client.SquareAPI).UpsertCatalogObject(obj); err != nil {
+			return attributeErrors(err, catalogModifierFields)
+		}
+	}
+
+	return resourceSquareCatalogModifierRead(d, meta)
+}
+
+func resourceSquareCatalogModifierDelete(d *schema.ResourceData, meta interface{}) error {
+	_, err := meta.(client.SquareAPI).DeleteCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// Sets the modifier data of obj from the configuration. The model has no on_by_default field, so
+// it is set on the object's Fields.
+func expandCatalogModifier(d *schema.ResourceData, obj *client.CatalogObject) {
+	modifier := &squaremodel.CatalogModifier{
+		ModifierListID: d.Get("modifier_list_id").(string),
+		Name:           d.Get("name").(string),
+		Ordinal:        int64(d.Get("ordinal").(int)),
+	}
+
+	if currency := d.Get("currency").(string); currency != "" {
+		modifier.PriceMoney = &squaremodel.Money{
+			Amount:   int64(d.Get("price").(int)),
+			Currency: currency,
+		}
+	}
+
+	obj.ModifierData = modifier
+	obj.SetField("modifier_data.on_by_default", d.Get("on_by_default").(bool))
+}
+
+func flattenCatalogModifier(obj *client.CatalogObject, d *schema.ResourceData) error {
+	modifier := obj.ModifierData
+	onByDefault, _ := obj.Field("modifier_data.on_by_default")
+
+	d.Set("modifier_list_id", modifier.ModifierListID)
+	d.Set("name", modifier.Name)
+	d.Set("on_by_default", onByDefault == true)
+	d.Set("ordinal", modifier.Ordinal)
+
+	if modifier.PriceMoney != nil {
+		d.Set("price", modifier.PriceMoney.Amount)
+		d.Set("currency", modifier.PriceMoney.Currency)
+	} else {
+		d.Set("price", 0)
+		d.Set("currency", "")
+	}
+
+	return nil
+}
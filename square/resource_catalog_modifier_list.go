@@ -0,0 +1,143 @@
+package square
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	squaremodel "github.com/jefflinse/square-connect/models"
+	"github.com/jefflinse/terraform-provider-square/square/client"
+)
+
+const (
+	// CatalogModifierListNameMaxLength is the maximum length for a modifier list's name.
+	CatalogModifierListNameMaxLength = 255
+
+	// ModifierListObjectType is the Square type for a catalog object describing a modifier list.
+	ModifierListObjectType = "MODIFIER_LIST"
+
+	// ModifierListSelectionTypeSingle allows at most one modifier of a list to be applied to an item.
+	ModifierListSelectionTypeSingle = "SINGLE"
+
+	// ModifierListSelectionTypeMultiple allows any number of modifiers of a list to be applied to an item.
+	ModifierListSelectionTypeMultiple = "MULTIPLE"
+)
+
+func resourceSquareCatalogModifierList() *schema.Resource {
+	return &schema.Resource{
+		Schema: withIdempotencyKey(withLocationAttributes(map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(v interface{}, k string) (wrns []string, errs []error) {
+					val := v.(string)
+					if len(val) > CatalogModifierListNameMaxLength {
+						errs = append(errs, fmt.Errorf("modifier list name '%s' exceeds max length of %d", val, CatalogModifierListNameMaxLength))
+					}
+					return
+				},
+			},
+			"ordinal": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"selection_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      ModifierListSelectionTypeSingle,
+				ValidateFunc: validation.StringInSlice([]string{ModifierListSelectionTypeSingle, ModifierListSelectionTypeMultiple}, false),
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		})),
+		Create:        resourceSquareCatalogModifierListCreate,
+		Read:          resourceSquareCatalogModifierListRead,
+		Update:        resourceSquareCatalogModifierListUpdate,
+		Delete:        resourceSquareCatalogModifierListDelete,
+		CustomizeDiff: validateCatalogObjectLocations,
+		Importer:      importCatalogObject(ModifierListObjectType),
+	}
+}
+
+func resourceSquareCatalogModifierListCreate(d *schema.ResourceData, meta interface{}) error {
+	created, err := meta.(client.SquareAPI).UpsertCatalogObject(expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+		ID:               newCatalogObjectID(d),
+		Type:             strPtr(ModifierListObjectType),
+		ModifierListData: expandCatalogModifierList(d),
+	}))
+	if err != nil {
+		return attributeErrors(err, nil)
+	}
+
+	d.SetId(*created.ID)
+
+	return resourceSquareCatalogModifierListRead(d, meta)
+}
+
+func resourceSquareCatalogModifierListRead(d *schema.ResourceData, meta interface{}) error {
+	obj, err := meta.(client.SquareAPI).RetrieveCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		log.Printf("[WARN] catalog modifier list %s no longer exists, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	d.Set("version", obj.Version)
+	flattenCatalogObjectLocations(obj, d)
+
+	return flattenCatalogModifierList(obj.ModifierListData, d)
+}
+
+func resourceSquareCatalogModifierListUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("absent_at_location_ids") ||
+		d.HasChange("name") ||
+		d.HasChange("ordinal") ||
+		d.HasChange("present_at_all_locations") ||
+		d.HasChange("present_at_location_ids") ||
+		d.HasChange("selection_type") {
+
+		if _, err := meta.(client.SquareAPI).UpsertCatalogObject(expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+			ID:               strPtr(d.Id()),
+			Type:             strPtr(ModifierListObjectType),
+			Version:          int64(d.Get("version").(int)),
+			ModifierListData: expandCatalogModifierList(d),
+		})); err != nil {
+			return attributeErrors(err, nil)
+		}
+	}
+
+	return resourceSquareCatalogModifierListRead(d, meta)
+}
+
+func resourceSquareCatalogModifierListDelete(d *schema.ResourceData, meta interface{}) error {
+	_, err := meta.(client.SquareAPI).DeleteCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// The list's modifiers are managed by square_catalog_modifier resources, so they are left out
+// and Square keeps the ones the list already has.
+func expandCatalogModifierList(d *schema.ResourceData) *squaremodel.CatalogModifierList {
+	return &squaremodel.CatalogModifierList{
+		Name:          d.Get("name").(string),
+		Ordinal:       int64(d.Get("ordinal").(int)),
+		SelectionType: d.Get("selection_type").(string),
+	}
+}
+
+func flattenCatalogModifierList(modifierList *squaremodel.CatalogModifierList, d *schema.ResourceData) error {
+	d.Set("name", modifierList.Name)
+	d.Set("ordinal", modifierList.Ordinal)
+	d.Set("selection_type", modifierList.SelectionType)
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package square
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSquareCatalogModifierList_update(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_modifier_list"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogModifierListConfig("Milk choice", 1, "SINGLE"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_modifier_list.test"),
+					testAccCheckResourceID("square_catalog_modifier_list.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_modifier_list.test", "name", "Milk choice"),
+					resource.TestCheckResourceAttr("square_catalog_modifier_list.test", "ordinal", "1"),
+					resource.TestCheckResourceAttr("square_catalog_modifier_list.test", "selection_type", "SINGLE"),
+					resource.TestCheckResourceAttrSet("square_catalog_modifier_list.test", "version"),
+				),
+			},
+			{
+				Config: testAccSquareCatalogModifierListConfig("Extra shots", 2, "MULTIPLE"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_modifier_list.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_modifier_list.test", "name", "Extra shots"),
+					resource.TestCheckResourceAttr("square_catalog_modifier_list.test", "ordinal", "2"),
+					resource.TestCheckResourceAttr("square_catalog_modifier_list.test", "selection_type", "MULTIPLE"),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_modifier_list.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+		},
+	})
+}
+
+func testAccSquareCatalogModifierListConfig(name string, ordinal int, selectionType string) string {
+	return fmt.Sprintf(`
+resource "square_catalog_modifier_list" "test" {
+  name           = %q
+  ordinal        = %d
+  selection_type = %q
+}
+`, name, ordinal, selectionType)
+}
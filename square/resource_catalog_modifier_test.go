@@ -0,0 +1,133 @@
+package square
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSquareCatalogModifier_update(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_modifier"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogModifierConfigPriced("Oat milk", 1, 50, "USD"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_modifier.test"),
+					testAccCheckResourceID("square_catalog_modifier.test", &id, false),
+					resource.TestCheckResourceAttrPair("square_catalog_modifier.test", "modifier_list_id", "square_catalog_modifier_list.test", "id"),
+					resource.TestCheckResourceAttr("square_catalog_modifier.test", "name", "Oat milk"),
+					resource.TestCheckResourceAttr("square_catalog_modifier.test", "ordinal", "1"),
+					resource.TestCheckResourceAttr("square_catalog_modifier.test", "price", "50"),
+					resource.TestCheckResourceAttr("square_catalog_modifier.test", "currency", "USD"),
+					resource.TestCheckResourceAttr("square_catalog_modifier.test", "on_by_default", "false"),
+					resource.TestCheckResourceAttrSet("square_catalog_modifier.test", "version"),
+				),
+			},
+			{
+				Config: testAccSquareCatalogModifierConfigPriced("Almond milk", 2, 75, "CAD"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_modifier.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_modifier.test", "name", "Almond milk"),
+					resource.TestCheckResourceAttr("square_catalog_modifier.test", "ordinal", "2"),
+					resource.TestCheckResourceAttr("square_catalog_modifier.test", "price", "75"),
+					resource.TestCheckResourceAttr("square_catalog_modifier.test", "currency", "CAD"),
+				),
+			},
+			{
+				// Removing the price makes the modifier free.
+				Config: testAccSquareCatalogModifierConfigFree("Whole milk"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_modifier.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_modifier.test", "name", "Whole milk"),
+					resource.TestCheckResourceAttr("square_catalog_modifier.test", "price", "0"),
+					resource.TestCheckResourceAttr("square_catalog_modifier.test", "currency", ""),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_modifier.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogModifier_onByDefault(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_modifier"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogModifierConfigOnByDefault("milk", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_modifier.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_modifier.test", "on_by_default", "true"),
+				),
+			},
+			{
+				Config: testAccSquareCatalogModifierConfigOnByDefault("milk", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_modifier.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_modifier.test", "on_by_default", "false"),
+				),
+			},
+			{
+				// A modifier cannot be moved to another list, so it is replaced.
+				Config: testAccSquareCatalogModifierConfigOnByDefault("sweetener", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_modifier.test", &id, true),
+					resource.TestCheckResourceAttrPair("square_catalog_modifier.test", "modifier_list_id", "square_catalog_modifier_list.sweetener", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogModifier_invalid(t *testing.T) {
+	testAccRunRejectedConfigs(t, []testAccRejectedConfig{
+		{
+			name:   "price without currency",
+			config: testAccSquareCatalogModifierConfigPriced("Oat milk", 1, 50, ""),
+			err:    "price needs a currency",
+		},
+	})
+}
+
+func testAccSquareCatalogModifierConfigPriced(name string, ordinal, price int, currency string) string {
+	return testAccCatalogModifierListConfig("test", "Milk choice") + fmt.Sprintf(`
+resource "square_catalog_modifier" "test" {
+  modifier_list_id = square_catalog_modifier_list.test.id
+  name             = %q
+  ordinal          = %d
+  price            = %d
+  currency         = %q
+}
+`, name, ordinal, price, currency)
+}
+
+func testAccSquareCatalogModifierConfigFree(name string) string {
+	return testAccCatalogModifierListConfig("test", "Milk choice") + fmt.Sprintf(`
+resource "square_catalog_modifier" "test" {
+  modifier_list_id = square_catalog_modifier_list.test.id
+  name             = %q
+}
+`, name)
+}
+
+func testAccSquareCatalogModifierConfigOnByDefault(list string, onByDefault bool) string {
+	return testAccCatalogModifierListConfig("milk", "Milk choice") + testAccCatalogModifierListConfig("sweetener", "Sweetener") + fmt.Sprintf(`
+resource "square_catalog_modifier" "test" {
+  modifier_list_id = square_catalog_modifier_list.%s.id
+  name             = "Whole milk"
+  on_by_default    = %t
+}
+`, list, onByDefault)
+}
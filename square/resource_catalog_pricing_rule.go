@@ -0,0 +1,223 @@
+package square
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	squaremodel "github.com/jefflinse/square-connect/models"
+	"github.com/jefflinse/terraform-provider-square/square/client"
+)
+
+const (
+	// CatalogPricingRuleNameMaxLength is the maximum length for a pricing rule's name.
+	CatalogPricingRuleNameMaxLength = 255
+
+	// PricingRuleObjectType is the Square type for a catalog object describing a pricing rule.
+	PricingRuleObjectType = "PRICING_RULE"
+
+	// PricingRuleExcludeStrategyLeastExpensive excludes the least expensive matched products.
+	PricingRuleExcludeStrategyLeastExpensive = "LEAST_EXPENSIVE"
+
+	// PricingRuleExcludeStrategyMostExpensive excludes the most expensive matched products.
+	PricingRuleExcludeStrategyMostExpensive = "MOST_EXPENSIVE"
+
+	// pricingRuleDateLayout is the layout of a pricing rule's valid_from_date and valid_until_date.
+	pricingRuleDateLayout = "2006-01-02"
+
+	// pricingRuleLocalTimeLayout is the layout of a pricing rule's valid_from_local_time and valid_until_local_time.
+	pricingRuleLocalTimeLayout = "15:04:05"
+)
+
+func resourceSquareCatalogPricingRule() *schema.Resource {
+	return &schema.Resource{
+		Schema: withIdempotencyKey(withLocationAttributes(map[string]*schema.Schema{
+			"discount_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"exclude_products_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"exclude_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{PricingRuleExcludeStrategyLeastExpensive, PricingRuleExcludeStrategyMostExpensive}, false),
+			},
+			"match_products_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(v interface{}, k string) (wrns []string, errs []error) {
+					val := v.(string)
+					if len(val) > CatalogPricingRuleNameMaxLength {
+						errs = append(errs, fmt.Errorf("pricing rule name '%s' exceeds max length of %d", val, CatalogPricingRuleNameMaxLength))
+					}
+					return
+				},
+			},
+			"time_period_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"valid_from_date": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateTimeLayout(pricingRuleDateLayout, "date such as 2020-12-31"),
+			},
+			"valid_from_local_time": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateTimeLayout(pricingRuleLocalTimeLayout, "local time such as 17:00:00"),
+			},
+			"valid_until_date": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateTimeLayout(pricingRuleDateLayout, "date such as 2020-12-31"),
+			},
+			"valid_until_local_time": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateTimeLayout(pricingRuleLocalTimeLayout, "local time such as 17:00:00"),
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		})),
+		Create:        resourceSquareCatalogPricingRuleCreate,
+		Read:          resourceSquareCatalogPricingRuleRead,
+		Update:        resourceSquareCatalogPricingRuleUpdate,
+		Delete:        resourceSquareCatalogPricingRuleDelete,
+		CustomizeDiff: resourceSquareCatalogPricingRuleCustomizeDiff,
+		Importer:      importCatalogObject(PricingRuleObjectType),
+	}
+}
+
+// The layouts of the validity dates sort lexically, so they can be compared as strings.
+func resourceSquareCatalogPricingRuleCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateCatalogObjectLocations(d, meta); err != nil {
+		return err
+	}
+
+	from, until := d.Get("valid_from_date").(string), d.Get("valid_until_date").(string)
+	if from != "" && until != "" && from > until {
+		return fmt.Errorf("valid_from_date (%s) must not be after valid_until_date (%s)", from, until)
+	}
+
+	return nil
+}
+
+func resourceSquareCatalogPricingRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	created, err := meta.(client.SquareAPI).UpsertCatalogObject(expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+		ID:              newCatalogObjectID(d),
+		Type:            strPtr(PricingRuleObjectType),
+		PricingRuleData: expandCatalogPricingRule(d),
+	}))
+	if err != nil {
+		return attributeErrors(err, nil)
+	}
+
+	d.SetId(*created.ID)
+
+	return resourceSquareCatalogPricingRuleRead(d, meta)
+}
+
+func resourceSquareCatalogPricingRuleRead(d *schema.ResourceData, meta interface{}) error {
+	obj, err := meta.(client.SquareAPI).RetrieveCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		log.Printf("[WARN] catalog pricing rule %s no longer exists, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	d.Set("version", obj.Version)
+	flattenCatalogObjectLocations(obj, d)
+
+	return flattenCatalogPricingRule(obj.PricingRuleData, d)
+}
+
+func resourceSquareCatalogPricingRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("absent_at_location_ids") ||
+		d.HasChange("discount_id") ||
+		d.HasChange("exclude_products_id") ||
+		d.HasChange("exclude_strategy") ||
+		d.HasChange("match_products_id") ||
+		d.HasChange("name") ||
+		d.HasChange("present_at_all_locations") ||
+		d.HasChange("present_at_location_ids") ||
+		d.HasChange("time_period_ids") ||
+		d.HasChange("valid_from_date") ||
+		d.HasChange("valid_from_local_time") ||
+		d.HasChange("valid_until_date") ||
+		d.HasChange("valid_until_local_time") {
+
+		if _, err := meta.(client.SquareAPI).UpsertCatalogObject(expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+			ID:              strPtr(d.Id()),
+			Type:            strPtr(PricingRuleObjectType),
+			Version:         int64(d.Get("version").(int)),
+			PricingRuleData: expandCatalogPricingRule(d),
+		})); err != nil {
+			return attributeErrors(err, nil)
+		}
+	}
+
+	return resourceSquareCatalogPricingRuleRead(d, meta)
+}
+
+func resourceSquareCatalogPricingRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	_, err := meta.(client.SquareAPI).DeleteCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+func expandCatalogPricingRule(d *schema.ResourceData) *squaremodel.CatalogPricingRule {
+	pricingRule := &squaremodel.CatalogPricingRule{
+		DiscountID:          d.Get("discount_id").(string),
+		ExcludeProductsID:   d.Get("exclude_products_id").(string),
+		ExcludeStrategy:     d.Get("exclude_strategy").(string),
+		MatchProductsID:     d.Get("match_products_id").(string),
+		Name:                d.Get("name").(string),
+		TimePeriodIds:       []string{},
+		ValidFromDate:       d.Get("valid_from_date").(string),
+		ValidFromLocalTime:  d.Get("valid_from_local_time").(string),
+		ValidUntilDate:      d.Get("valid_until_date").(string),
+		ValidUntilLocalTime: d.Get("valid_until_local_time").(string),
+	}
+
+	for _, id := range d.Get("time_period_ids").(*schema.Set).List() {
+		pricingRule.TimePeriodIds = append(pricingRule.TimePeriodIds, id.(string))
+	}
+
+	return pricingRule
+}
+
+func flattenCatalogPricingRule(pricingRule *squaremodel.CatalogPricingRule, d *schema.ResourceData) error {
+	d.Set("discount_id", pricingRule.DiscountID)
+	d.Set("exclude_products_id", pricingRule.ExcludeProductsID)
+	d.Set("exclude_strategy", pricingRule.ExcludeStrategy)
+	d.Set("match_products_id", pricingRule.MatchProductsID)
+	d.Set("name", pricingRule.Name)
+	d.Set("time_period_ids", pricingRule.TimePeriodIds)
+	d.Set("valid_from_date", pricingRule.ValidFromDate)
+	d.Set("valid_from_local_time", pricingRule.ValidFromLocalTime)
+	d.Set("valid_until_date", pricingRule.ValidUntilDate)
+	d.Set("valid_until_local_time", pricingRule.ValidUntilLocalTime)
+
+	return nil
+}
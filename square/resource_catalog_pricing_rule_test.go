@@ -0,0 +1,125 @@
+package square
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSquareCatalogPricingRule_update(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_pricing_rule"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogPricingRuleConfigHappyHour("Happy hour"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_pricing_rule.test"),
+					testAccCheckResourceID("square_catalog_pricing_rule.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_pricing_rule.test", "name", "Happy hour"),
+					resource.TestCheckResourceAttrPair("square_catalog_pricing_rule.test", "discount_id", "square_catalog_discount.test", "id"),
+					resource.TestCheckResourceAttrPair("square_catalog_pricing_rule.test", "match_products_id", "square_catalog_product_set.drinks", "id"),
+					resource.TestCheckResourceAttr("square_catalog_pricing_rule.test", "exclude_products_id", ""),
+					resource.TestCheckResourceAttr("square_catalog_pricing_rule.test", "exclude_strategy", PricingRuleExcludeStrategyLeastExpensive),
+					resource.TestCheckResourceAttr("square_catalog_pricing_rule.test", "time_period_ids.#", "1"),
+					resource.TestCheckResourceAttr("square_catalog_pricing_rule.test", "valid_from_date", "2020-12-01"),
+					resource.TestCheckResourceAttr("square_catalog_pricing_rule.test", "valid_until_date", "2021-03-31"),
+					resource.TestCheckResourceAttrSet("square_catalog_pricing_rule.test", "version"),
+				),
+			},
+			{
+				Config: testAccSquareCatalogPricingRuleConfigBOGO("Buy one get one"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_pricing_rule.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_pricing_rule.test", "name", "Buy one get one"),
+					resource.TestCheckResourceAttrPair("square_catalog_pricing_rule.test", "match_products_id", "square_catalog_product_set.two_drinks", "id"),
+					resource.TestCheckResourceAttrPair("square_catalog_pricing_rule.test", "exclude_products_id", "square_catalog_product_set.drinks", "id"),
+					resource.TestCheckResourceAttr("square_catalog_pricing_rule.test", "exclude_strategy", PricingRuleExcludeStrategyMostExpensive),
+					resource.TestCheckResourceAttr("square_catalog_pricing_rule.test", "time_period_ids.#", "0"),
+					resource.TestCheckResourceAttr("square_catalog_pricing_rule.test", "valid_from_date", ""),
+					resource.TestCheckResourceAttr("square_catalog_pricing_rule.test", "valid_from_local_time", "11:00:00"),
+					resource.TestCheckResourceAttr("square_catalog_pricing_rule.test", "valid_until_date", ""),
+					resource.TestCheckResourceAttr("square_catalog_pricing_rule.test", "valid_until_local_time", "14:00:00"),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_pricing_rule.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogPricingRule_invalidDates(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogPricingRuleConfigProducts() + `
+resource "square_catalog_pricing_rule" "test" {
+  name              = "Backwards"
+  discount_id       = square_catalog_discount.test.id
+  match_products_id = square_catalog_product_set.drinks.id
+  valid_from_date   = "2021-01-01"
+  valid_until_date  = "2020-12-31"
+}
+`,
+				ExpectError: regexp.MustCompile(`valid_from_date \(2021-01-01\) must not be after valid_until_date \(2020-12-31\)`),
+			},
+		},
+	})
+}
+
+// Configures the discount and the product set the pricing rules apply it to.
+func testAccSquareCatalogPricingRuleConfigProducts() string {
+	return testAccSquareCatalogDiscountConfigPercentage("Half off", "50.0") + testAccCatalogCategoryConfig("drinks", "Drinks") + `
+resource "square_catalog_product_set" "drinks" {
+  product_ids_any = [square_catalog_category.drinks.id]
+}
+`
+}
+
+func testAccSquareCatalogPricingRuleConfigHappyHour(name string) string {
+	return testAccSquareCatalogPricingRuleConfigProducts() + fmt.Sprintf(`
+resource "square_catalog_time_period" "happy_hour" {
+  start    = "2020-12-07T17:00:00"
+  duration = "PT2H"
+  rrule    = "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"
+}
+
+resource "square_catalog_pricing_rule" "test" {
+  name              = %q
+  discount_id       = square_catalog_discount.test.id
+  match_products_id = square_catalog_product_set.drinks.id
+  time_period_ids   = [square_catalog_time_period.happy_hour.id]
+  valid_from_date   = "2020-12-01"
+  valid_until_date  = "2021-03-31"
+}
+`, name)
+}
+
+func testAccSquareCatalogPricingRuleConfigBOGO(name string) string {
+	return testAccSquareCatalogPricingRuleConfigProducts() + fmt.Sprintf(`
+resource "square_catalog_product_set" "two_drinks" {
+  product_ids_any = [square_catalog_category.drinks.id]
+  quantity_exact  = 2
+}
+
+resource "square_catalog_pricing_rule" "test" {
+  name                   = %q
+  discount_id            = square_catalog_discount.test.id
+  match_products_id      = square_catalog_product_set.two_drinks.id
+  exclude_products_id    = square_catalog_product_set.drinks.id
+  exclude_strategy       = "MOST_EXPENSIVE"
+  valid_from_local_time  = "11:00:00"
+  valid_until_local_time = "14:00:00"
+}
+`, name)
+}
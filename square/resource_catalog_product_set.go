@@ -0,0 +1,209 @@
+package square
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	squaremodel "github.com/jefflinse/square-connect/models"
+	"github.com/jefflinse/terraform-provider-square/square/client"
+)
+
+const (
+	// CatalogProductSetNameMaxLength is the maximum length for a product set's name.
+	CatalogProductSetNameMaxLength = 255
+
+	// ProductSetObjectType is the Square type for a catalog object describing a product set.
+	ProductSetObjectType = "PRODUCT_SET"
+)
+
+func resourceSquareCatalogProductSet() *schema.Resource {
+	return &schema.Resource{
+		Schema: withIdempotencyKey(withLocationAttributes(map[string]*schema.Schema{
+			"all_products": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"product_ids_all", "product_ids_any"},
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(v interface{}, k string) (wrns []string, errs []error) {
+					val := v.(string)
+					if len(val) > CatalogProductSetNameMaxLength {
+						errs = append(errs, fmt.Errorf("product set name '%s' exceeds max length of %d", val, CatalogProductSetNameMaxLength))
+					}
+					return
+				},
+			},
+			"product_ids_all": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"all_products", "product_ids_any"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"product_ids_any": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"all_products", "product_ids_all"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"quantity_exact": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"quantity_max", "quantity_min"},
+				ValidateFunc:  validation.IntBetween(1, math.MaxInt32),
+			},
+			"quantity_max": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"quantity_exact"},
+				ValidateFunc:  validation.IntBetween(1, math.MaxInt32),
+			},
+			"quantity_min": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"quantity_exact"},
+				ValidateFunc:  validation.IntBetween(1, math.MaxInt32),
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		})),
+		Create:        resourceSquareCatalogProductSetCreate,
+		Read:          resourceSquareCatalogProductSetRead,
+		Update:        resourceSquareCatalogProductSetUpdate,
+		Delete:        resourceSquareCatalogProductSetDelete,
+		CustomizeDiff: resourceSquareCatalogProductSetCustomizeDiff,
+		Importer:      importCatalogObject(ProductSetObjectType),
+	}
+}
+
+// A product set must match something, and a minimum quantity above the maximum matches nothing.
+func resourceSquareCatalogProductSetCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateCatalogObjectLocations(d, meta); err != nil {
+		return err
+	}
+
+	if d.NewValueKnown("product_ids_all.#") && d.NewValueKnown("product_ids_any.#") &&
+		!d.Get("all_products").(bool) &&
+		d.Get("product_ids_all").(*schema.Set).Len() == 0 &&
+		d.Get("product_ids_any").(*schema.Set).Len() == 0 {
+		return fmt.Errorf("one of all_products, product_ids_all, or product_ids_any must be set")
+	}
+
+	min, max := d.Get("quantity_min").(int), d.Get("quantity_max").(int)
+	if min != 0 && max != 0 && min > max {
+		return fmt.Errorf("quantity_min (%d) must not exceed quantity_max (%d)", min, max)
+	}
+
+	return nil
+}
+
+func resourceSquareCatalogProductSetCreate(d *schema.ResourceData, meta interface{}) error {
+	created, err := meta.(client.SquareAPI).UpsertCatalogObject(expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+		ID:             newCatalogObjectID(d),
+		Type:           strPtr(ProductSetObjectType),
+		ProductSetData: expandCatalogProductSet(d),
+	}))
+	if err != nil {
+		return attributeErrors(err, nil)
+	}
+
+	d.SetId(*created.ID)
+
+	return resourceSquareCatalogProductSetRead(d, meta)
+}
+
+func resourceSquareCatalogProductSetRead(d *schema.ResourceData, meta interface{}) error {
+	obj, err := meta.(client.SquareAPI).RetrieveCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		log.Printf("[WARN] catalog product set %s no longer exists, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	d.Set("version", obj.Version)
+	flattenCatalogObjectLocations(obj, d)
+
+	return flattenCatalogProductSet(obj.ProductSetData, d)
+}
+
+func resourceSquareCatalogProductSetUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("absent_at_location_ids") ||
+		d.HasChange("all_products") ||
+		d.HasChange("name") ||
+		d.HasChange("present_at_all_locations") ||
+		d.HasChange("present_at_location_ids") ||
+		d.HasChange("product_ids_all") ||
+		d.HasChange("product_ids_any") ||
+		d.HasChange("quantity_exact") ||
+		d.HasChange("quantity_max") ||
+		d.HasChange("quantity_min") {
+
+		if _, err := meta.(client.SquareAPI).UpsertCatalogObject(expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+			ID:             strPtr(d.Id()),
+			Type:           strPtr(ProductSetObjectType),
+			Version:        int64(d.Get("version").(int)),
+			ProductSetData: expandCatalogProductSet(d),
+		})); err != nil {
+			return attributeErrors(err, nil)
+		}
+	}
+
+	return resourceSquareCatalogProductSetRead(d, meta)
+}
+
+func resourceSquareCatalogProductSetDelete(d *schema.ResourceData, meta interface{}) error {
+	_, err := meta.(client.SquareAPI).DeleteCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// Square rejects a product set with more than one of its product lists present, even an empty one,
+// so lists that are not configured are left out of the request entirely.
+func expandCatalogProductSet(d *schema.ResourceData) *squaremodel.CatalogProductSet {
+	productSet := &squaremodel.CatalogProductSet{
+		AllProducts:   d.Get("all_products").(bool),
+		Name:          d.Get("name").(string),
+		QuantityExact: int64(d.Get("quantity_exact").(int)),
+		QuantityMax:   int64(d.Get("quantity_max").(int)),
+		QuantityMin:   int64(d.Get("quantity_min").(int)),
+	}
+
+	for _, id := range d.Get("product_ids_all").(*schema.Set).List() {
+		productSet.ProductIdsAll = append(productSet.ProductIdsAll, id.(string))
+	}
+
+	for _, id := range d.Get("product_ids_any").(*schema.Set).List() {
+		productSet.ProductIdsAny = append(productSet.ProductIdsAny, id.(string))
+	}
+
+	return productSet
+}
+
+func flattenCatalogProductSet(productSet *squaremodel.CatalogProductSet, d *schema.ResourceData) error {
+	d.Set("all_products", productSet.AllProducts)
+	d.Set("name", productSet.Name)
+	d.Set("product_ids_all", productSet.ProductIdsAll)
+	d.Set("product_ids_any", productSet.ProductIdsAny)
+	d.Set("quantity_exact", productSet.QuantityExact)
+	d.Set("quantity_max", productSet.QuantityMax)
+	d.Set("quantity_min", productSet.QuantityMin)
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package square
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSquareCatalogProductSet_update(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_product_set"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogProductSetConfigAny("Any drink", 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_product_set.test"),
+					testAccCheckResourceID("square_catalog_product_set.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_product_set.test", "name", "Any drink"),
+					resource.TestCheckResourceAttr("square_catalog_product_set.test", "all_products", "false"),
+					resource.TestCheckResourceAttr("square_catalog_product_set.test", "product_ids_any.#", "2"),
+					resource.TestCheckResourceAttr("square_catalog_product_set.test", "product_ids_all.#", "0"),
+					resource.TestCheckResourceAttr("square_catalog_product_set.test", "quantity_exact", "2"),
+					resource.TestCheckResourceAttrSet("square_catalog_product_set.test", "version"),
+				),
+			},
+			{
+				Config: testAccSquareCatalogProductSetConfigAll("Tea and coffee", 1, 4),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_product_set.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_product_set.test", "name", "Tea and coffee"),
+					resource.TestCheckResourceAttr("square_catalog_product_set.test", "product_ids_any.#", "0"),
+					resource.TestCheckResourceAttr("square_catalog_product_set.test", "product_ids_all.#", "2"),
+					resource.TestCheckResourceAttr("square_catalog_product_set.test", "quantity_exact", "0"),
+					resource.TestCheckResourceAttr("square_catalog_product_set.test", "quantity_min", "1"),
+					resource.TestCheckResourceAttr("square_catalog_product_set.test", "quantity_max", "4"),
+				),
+			},
+			{
+				Config: testAccSquareCatalogProductSetConfigEverything("Everything"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_product_set.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_product_set.test", "name", "Everything"),
+					resource.TestCheckResourceAttr("square_catalog_product_set.test", "all_products", "true"),
+					resource.TestCheckResourceAttr("square_catalog_product_set.test", "product_ids_any.#", "0"),
+					resource.TestCheckResourceAttr("square_catalog_product_set.test", "product_ids_all.#", "0"),
+					resource.TestCheckResourceAttr("square_catalog_product_set.test", "quantity_min", "0"),
+					resource.TestCheckResourceAttr("square_catalog_product_set.test", "quantity_max", "0"),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_product_set.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogProductSet_invalid(t *testing.T) {
+	testAccRunRejectedConfigs(t, []testAccRejectedConfig{
+		{
+			name: "no products",
+			config: `
+resource "square_catalog_product_set" "test" {
+  name = "Nothing"
+}
+`,
+			err: "one of all_products, product_ids_all, or product_ids_any must be set",
+		},
+		{
+			name: "minimum above maximum",
+			config: `
+resource "square_catalog_product_set" "test" {
+  all_products = true
+  quantity_min = 3
+  quantity_max = 2
+}
+`,
+			err: `quantity_min \(3\) must not exceed quantity_max \(2\)`,
+		},
+		{
+			name: "negative quantity",
+			config: `
+resource "square_catalog_product_set" "test" {
+  all_products   = true
+  quantity_exact = -1
+}
+`,
+			err: `expected quantity_exact to be in the range \(1 - 2147483647\), got -1`,
+		},
+	})
+}
+
+func testAccSquareCatalogProductSetConfigProducts() string {
+	return testAccCatalogCategoryConfig("tea", "Tea") + testAccCatalogCategoryConfig("coffee", "Coffee")
+}
+
+func testAccSquareCatalogProductSetConfigAny(name string, quantity int) string {
+	return testAccSquareCatalogProductSetConfigProducts() + fmt.Sprintf(`
+resource "square_catalog_product_set" "test" {
+  name            = %q
+  product_ids_any = [square_catalog_category.tea.id, square_catalog_category.coffee.id]
+  quantity_exact  = %d
+}
+`, name, quantity)
+}
+
+func testAccSquareCatalogProductSetConfigAll(name string, min, max int) string {
+	return testAccSquareCatalogProductSetConfigProducts() + fmt.Sprintf(`
+resource "square_catalog_product_set" "test" {
+  name            = %q
+  product_ids_all = [square_catalog_category.tea.id, square_catalog_category.coffee.id]
+  quantity_min    = %d
+  quantity_max    = %d
+}
+`, name, min, max)
+}
+
+func testAccSquareCatalogProductSetConfigEverything(name string) string {
+	return fmt.Sprintf(`
+resource "square_catalog_product_set" "test" {
+  name         = %q
+  all_products = true
+}
+`, name)
+}
@@ -1,7 +1,9 @@
 package square
 
 import (
+	"errors"
 	"fmt"
+	"log"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	squaremodel "github.com/jefflinse/square-connect/models"
@@ -24,7 +26,7 @@ const (
 
 func resourceSquareCatalogTax() *schema.Resource {
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
+		Schema: withIdempotencyKey(withLocationAttributes(map[string]*schema.Schema{
 			"applies_to_custom_amounts": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -58,22 +60,28 @@ func resourceSquareCatalogTax() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
-		},
-		Create: resourceSquareCatalogTaxCreate,
-		Read:   resourceSquareCatalogTaxRead,
-		Update: resourceSquareCatalogTaxUpdate,
-		Delete: resourceSquareCatalogTaxDelete,
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		})),
+		Create:        resourceSquareCatalogTaxCreate,
+		Read:          resourceSquareCatalogTaxRead,
+		Update:        resourceSquareCatalogTaxUpdate,
+		Delete:        resourceSquareCatalogTaxDelete,
+		CustomizeDiff: validateCatalogObjectLocations,
+		Importer:      importCatalogObject(TaxObjectType),
 	}
 }
 
 func resourceSquareCatalogTaxCreate(d *schema.ResourceData, meta interface{}) error {
-	created, err := meta.(client.SquareAPI).UpsertCatalogObject(&squaremodel.CatalogObject{
-		ID:      newTempID(),
+	created, err := meta.(client.SquareAPI).UpsertCatalogObject(expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+		ID:      newCatalogObjectID(d),
 		Type:    strPtr(TaxObjectType),
 		TaxData: expandCatalogTax(d),
-	})
+	}))
 	if err != nil {
-		return err
+		return attributeErrors(err, nil)
 	}
 
 	d.SetId(*created.ID)
@@ -83,46 +91,50 @@ func resourceSquareCatalogTaxCreate(d *schema.ResourceData, meta interface{}) er
 
 func resourceSquareCatalogTaxRead(d *schema.ResourceData, meta interface{}) error {
 	obj, err := meta.(client.SquareAPI).RetrieveCatalogObject(d.Id())
-	if err != nil {
+	if errors.Is(err, client.ErrNotFound) {
+		log.Printf("[WARN] catalog tax %s no longer exists, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	} else if err != nil {
 		return err
 	}
 
+	d.Set("version", obj.Version)
+	flattenCatalogObjectLocations(obj, d)
+
 	return flattenCatalogTax(obj.TaxData, d)
 }
 
 func resourceSquareCatalogTaxUpdate(d *schema.ResourceData, meta interface{}) error {
-	if d.HasChange("abbreviation") ||
-		d.HasChange("available_electronically") ||
-		d.HasChange("available_for_pickup") ||
-		d.HasChange("available_online") ||
-		d.HasChange("category_id") ||
-		d.HasChange("description") ||
-		d.HasChange("label_color") ||
+	if d.HasChange("absent_at_location_ids") ||
+		d.HasChange("applies_to_custom_amounts") ||
+		d.HasChange("calculation_phase") ||
+		d.HasChange("enabled") ||
+		d.HasChange("inclusion_type") ||
 		d.HasChange("name") ||
-		d.HasChange("skip_modifier_screen") ||
-		d.HasChange("tax_ids") {
-
-		client := meta.(client.SquareAPI)
-		obj, err := client.RetrieveCatalogObject(d.Id())
-		if err != nil {
-			return err
-		}
+		d.HasChange("percentage") ||
+		d.HasChange("present_at_all_locations") ||
+		d.HasChange("present_at_location_ids") {
 
-		if _, err := client.UpsertCatalogObject(&squaremodel.CatalogObject{
-			ID:      strPtr(*obj.ID),
+		if _, err := meta.(client.SquareAPI).UpsertCatalogObject(expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+			ID:      strPtr(d.Id()),
 			Type:    strPtr(TaxObjectType),
-			Version: obj.Version,
+			Version: int64(d.Get("version").(int)),
 			TaxData: expandCatalogTax(d),
-		}); err != nil {
-			return err
+		})); err != nil {
+			return attributeErrors(err, nil)
 		}
 	}
 
-	return nil
+	return resourceSquareCatalogTaxRead(d, meta)
 }
 
 func resourceSquareCatalogTaxDelete(d *schema.ResourceData, meta interface{}) error {
 	_, err := meta.(client.SquareAPI).DeleteCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		return nil
+	}
+
 	return err
 }
 
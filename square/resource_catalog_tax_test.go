@@ -0,0 +1,70 @@
+package square
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSquareCatalogTax_update(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_tax"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogTaxConfig("Sales Tax", "TAX_SUBTOTAL_PHASE", "ADDITIVE", "8.5", false, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_tax.test"),
+					testAccCheckResourceID("square_catalog_tax.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_tax.test", "name", "Sales Tax"),
+					resource.TestCheckResourceAttr("square_catalog_tax.test", "calculation_phase", "TAX_SUBTOTAL_PHASE"),
+					resource.TestCheckResourceAttr("square_catalog_tax.test", "inclusion_type", "ADDITIVE"),
+					resource.TestCheckResourceAttr("square_catalog_tax.test", "percentage", "8.5"),
+					resource.TestCheckResourceAttr("square_catalog_tax.test", "applies_to_custom_amounts", "false"),
+					resource.TestCheckResourceAttr("square_catalog_tax.test", "enabled", "false"),
+				),
+			},
+			{
+				Config: testAccSquareCatalogTaxConfig("VAT", "TAX_TOTAL_PHASE", "INCLUSIVE", "20.0", true, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_tax.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_tax.test", "name", "VAT"),
+					resource.TestCheckResourceAttr("square_catalog_tax.test", "calculation_phase", "TAX_TOTAL_PHASE"),
+					resource.TestCheckResourceAttr("square_catalog_tax.test", "inclusion_type", "INCLUSIVE"),
+					resource.TestCheckResourceAttr("square_catalog_tax.test", "percentage", "20.0"),
+					resource.TestCheckResourceAttr("square_catalog_tax.test", "applies_to_custom_amounts", "true"),
+					resource.TestCheckResourceAttr("square_catalog_tax.test", "enabled", "true"),
+				),
+			},
+			{
+				Config: testAccSquareCatalogTaxConfig("VAT", "TAX_TOTAL_PHASE", "INCLUSIVE", "17.5", true, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_tax.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_tax.test", "percentage", "17.5"),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_tax.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+		},
+	})
+}
+
+func testAccSquareCatalogTaxConfig(name, phase, inclusion, percentage string, customAmounts, enabled bool) string {
+	return fmt.Sprintf(`
+resource "square_catalog_tax" "test" {
+  name                      = %q
+  calculation_phase         = %q
+  inclusion_type            = %q
+  percentage                = %q
+  applies_to_custom_amounts = %t
+  enabled                   = %t
+}
+`, name, phase, inclusion, percentage, customAmounts, enabled)
+}
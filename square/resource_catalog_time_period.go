@@ -0,0 +1,274 @@
+package square
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	squaremodel "github.com/jefflinse/square-connect/models"
+	"github.com/jefflinse/terraform-provider-square/square/client"
+)
+
+const (
+	// TimePeriodObjectType is the Square type for a catalog object describing a time period.
+	TimePeriodObjectType = "TIME_PERIOD"
+
+	// timePeriodStartLayout is the layout of a time period's start attribute, a local time.
+	timePeriodStartLayout = "2006-01-02T15:04:05"
+
+	// iCalDateTimeLayout is the layout of a local date-time in an iCalendar event.
+	iCalDateTimeLayout = "20060102T150405"
+)
+
+var (
+	// isoDurationPattern matches an ISO 8601 duration such as "PT2H" or "P1DT30M".
+	isoDurationPattern = regexp.MustCompile(`^P(\d+W|(\d+D)?(T(\d+H)?(\d+M)?(\d+S)?)?)$`)
+
+	// rruleWeekdayPattern matches a BYDAY entry of an RRULE, such as "MO" or "-1FR".
+	rruleWeekdayPattern = regexp.MustCompile(`^([+-]?([1-9]|[1-4][0-9]|5[0-3]))?(MO|TU|WE|TH|FR|SA|SU)$`)
+
+	// rruleUntilPattern matches the UNTIL date or date-time of an RRULE.
+	rruleUntilPattern = regexp.MustCompile(`^\d{8}(T\d{6}Z?)?$`)
+)
+
+// rruleIntegerParts maps the RRULE parts holding lists of integers to their allowed range.
+// Negative values count from the end of the period and are allowed where RFC 5545 allows them.
+var rruleIntegerParts = map[string]struct {
+	min, max int
+	negative bool
+}{
+	"BYSECOND":   {0, 60, false},
+	"BYMINUTE":   {0, 59, false},
+	"BYHOUR":     {0, 23, false},
+	"BYMONTHDAY": {1, 31, true},
+	"BYYEARDAY":  {1, 366, true},
+	"BYWEEKNO":   {1, 53, true},
+	"BYMONTH":    {1, 12, false},
+	"BYSETPOS":   {1, 366, true},
+}
+
+func resourceSquareCatalogTimePeriod() *schema.Resource {
+	return &schema.Resource{
+		Schema: withIdempotencyKey(withLocationAttributes(map[string]*schema.Schema{
+			"duration": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(v interface{}, k string) (wrns []string, errs []error) {
+					val := v.(string)
+					if !isoDurationPattern.MatchString(val) || val == "P" || strings.HasSuffix(val, "T") {
+						errs = append(errs, fmt.Errorf("%s '%s' is not an ISO 8601 duration such as PT2H", k, val))
+					}
+					return
+				},
+			},
+			"rrule": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRRule,
+			},
+			"start": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateTimeLayout(timePeriodStartLayout, "local date and time such as 2019-07-07T18:00:00"),
+			},
+			"summary": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		})),
+		Create:        resourceSquareCatalogTimePeriodCreate,
+		Read:          resourceSquareCatalogTimePeriodRead,
+		Update:        resourceSquareCatalogTimePeriodUpdate,
+		Delete:        resourceSquareCatalogTimePeriodDelete,
+		CustomizeDiff: validateCatalogObjectLocations,
+		Importer:      importCatalogObject(TimePeriodObjectType),
+	}
+}
+
+func resourceSquareCatalogTimePeriodCreate(d *schema.ResourceData, meta interface{}) error {
+	created, err := meta.(client.SquareAPI).UpsertCatalogObject(expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+		ID:             newCatalogObjectID(d),
+		Type:           strPtr(TimePeriodObjectType),
+		TimePeriodData: expandCatalogTimePeriod(d),
+	}))
+	if err != nil {
+		return attributeErrors(err, nil)
+	}
+
+	d.SetId(*created.ID)
+
+	return resourceSquareCatalogTimePeriodRead(d, meta)
+}
+
+func resourceSquareCatalogTimePeriodRead(d *schema.ResourceData, meta interface{}) error {
+	obj, err := meta.(client.SquareAPI).RetrieveCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		log.Printf("[WARN] catalog time period %s no longer exists, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	d.Set("version", obj.Version)
+	flattenCatalogObjectLocations(obj, d)
+
+	return flattenCatalogTimePeriod(obj.TimePeriodData, d)
+}
+
+func resourceSquareCatalogTimePeriodUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("absent_at_location_ids") ||
+		d.HasChange("duration") ||
+		d.HasChange("present_at_all_locations") ||
+		d.HasChange("present_at_location_ids") ||
+		d.HasChange("rrule") ||
+		d.HasChange("start") ||
+		d.HasChange("summary") {
+
+		if _, err := meta.(client.SquareAPI).UpsertCatalogObject(expandCatalogObjectLocations(d, &squaremodel.CatalogObject{
+			ID:             strPtr(d.Id()),
+			Type:           strPtr(TimePeriodObjectType),
+			Version:        int64(d.Get("version").(int)),
+			TimePeriodData: expandCatalogTimePeriod(d),
+		})); err != nil {
+			return attributeErrors(err, nil)
+		}
+	}
+
+	return resourceSquareCatalogTimePeriodRead(d, meta)
+}
+
+func resourceSquareCatalogTimePeriodDelete(d *schema.ResourceData, meta interface{}) error {
+	_, err := meta.(client.SquareAPI).DeleteCatalogObject(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// Square describes a time period as an iCalendar event supporting only the SUMMARY, DTSTART,
+// DURATION, and RRULE properties, each of which is an attribute of the resource.
+func expandCatalogTimePeriod(d *schema.ResourceData) *squaremodel.CatalogTimePeriod {
+	start, _ := time.Parse(timePeriodStartLayout, d.Get("start").(string))
+
+	lines := []string{"BEGIN:VEVENT"}
+	if summary := d.Get("summary").(string); summary != "" {
+		lines = append(lines, "SUMMARY:"+summary)
+	}
+	lines = append(lines, "DTSTART:"+start.Format(iCalDateTimeLayout), "DURATION:"+d.Get("duration").(string))
+	if rrule := d.Get("rrule").(string); rrule != "" {
+		lines = append(lines, "RRULE:"+rrule)
+	}
+	lines = append(lines, "END:VEVENT")
+
+	return &squaremodel.CatalogTimePeriod{
+		Event: strings.Join(lines, "\n"),
+	}
+}
+
+func flattenCatalogTimePeriod(timePeriod *squaremodel.CatalogTimePeriod, d *schema.ResourceData) error {
+	properties := map[string]string{}
+	for _, line := range strings.Split(strings.ReplaceAll(timePeriod.Event, "\r\n", "\n"), "\n") {
+		if i := strings.Index(line, ":"); i > 0 {
+			properties[line[:i]] = line[i+1:]
+		}
+	}
+
+	start, err := time.Parse(iCalDateTimeLayout, properties["DTSTART"])
+	if err != nil {
+		return fmt.Errorf("time period %s has an invalid DTSTART: %w", d.Id(), err)
+	}
+
+	d.Set("duration", properties["DURATION"])
+	d.Set("rrule", properties["RRULE"])
+	d.Set("start", start.Format(timePeriodStartLayout))
+	d.Set("summary", properties["SUMMARY"])
+
+	return nil
+}
+
+// Validates an iCalendar (RFC 5545) recurrence rule such as "FREQ=WEEKLY;BYDAY=MO,WE,FR".
+func validateRRule(v interface{}, k string) (wrns []string, errs []error) {
+	parts := map[string]string{}
+	for _, part := range strings.Split(v.(string), ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			errs = append(errs, fmt.Errorf("%s: '%s' is not a NAME=VALUE rule part", k, part))
+			continue
+		}
+
+		if _, ok := parts[kv[0]]; ok {
+			errs = append(errs, fmt.Errorf("%s: %s must not be repeated", k, kv[0]))
+		}
+		parts[kv[0]] = kv[1]
+	}
+
+	for name, value := range parts {
+		switch name {
+		case "FREQ":
+			switch value {
+			case "SECONDLY", "MINUTELY", "HOURLY", "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+			default:
+				errs = append(errs, fmt.Errorf("%s: FREQ '%s' is not a valid frequency", k, value))
+			}
+		case "UNTIL":
+			if !rruleUntilPattern.MatchString(value) {
+				errs = append(errs, fmt.Errorf("%s: UNTIL '%s' is not a date (YYYYMMDD) or date-time (YYYYMMDDTHHMMSS)", k, value))
+			}
+		case "COUNT", "INTERVAL":
+			if n, err := strconv.Atoi(value); err != nil || n < 1 {
+				errs = append(errs, fmt.Errorf("%s: %s '%s' must be a positive integer", k, name, value))
+			}
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				if !rruleWeekdayPattern.MatchString(day) {
+					errs = append(errs, fmt.Errorf("%s: BYDAY '%s' is not a weekday such as MO or -1FR", k, day))
+				}
+			}
+		case "WKST":
+			switch value {
+			case "MO", "TU", "WE", "TH", "FR", "SA", "SU":
+			default:
+				errs = append(errs, fmt.Errorf("%s: WKST '%s' is not a weekday", k, value))
+			}
+		default:
+			bounds, ok := rruleIntegerParts[name]
+			if !ok {
+				errs = append(errs, fmt.Errorf("%s: unknown rule part %s", k, name))
+				continue
+			}
+
+			for _, s := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(s)
+				if bounds.negative && n < 0 {
+					n = -n
+				}
+				if err != nil || n < bounds.min || n > bounds.max {
+					errs = append(errs, fmt.Errorf("%s: %s '%s' must be between %d and %d", k, name, s, bounds.min, bounds.max))
+				}
+			}
+		}
+	}
+
+	if _, ok := parts["FREQ"]; !ok {
+		errs = append(errs, fmt.Errorf("%s: FREQ is required", k))
+	}
+
+	if _, ok := parts["COUNT"]; ok {
+		if _, ok := parts["UNTIL"]; ok {
+			errs = append(errs, fmt.Errorf("%s: COUNT and UNTIL cannot both be set", k))
+		}
+	}
+
+	return
+}
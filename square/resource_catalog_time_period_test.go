@@ -0,0 +1,107 @@
+package square
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSquareCatalogTimePeriod_update(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_time_period"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareCatalogTimePeriodConfigRecurring("Happy hour", "2020-12-07T17:00:00", "PT2H", "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCatalogObjectExists("square_catalog_time_period.test"),
+					testAccCheckResourceID("square_catalog_time_period.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_time_period.test", "summary", "Happy hour"),
+					resource.TestCheckResourceAttr("square_catalog_time_period.test", "start", "2020-12-07T17:00:00"),
+					resource.TestCheckResourceAttr("square_catalog_time_period.test", "duration", "PT2H"),
+					resource.TestCheckResourceAttr("square_catalog_time_period.test", "rrule", "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"),
+					resource.TestCheckResourceAttrSet("square_catalog_time_period.test", "version"),
+				),
+			},
+			{
+				Config: testAccSquareCatalogTimePeriodConfigRecurring("Late night", "2020-12-11T22:30:00", "PT1H30M", "FREQ=MONTHLY;BYDAY=-1FR;COUNT=6"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_time_period.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_time_period.test", "summary", "Late night"),
+					resource.TestCheckResourceAttr("square_catalog_time_period.test", "start", "2020-12-11T22:30:00"),
+					resource.TestCheckResourceAttr("square_catalog_time_period.test", "duration", "PT1H30M"),
+					resource.TestCheckResourceAttr("square_catalog_time_period.test", "rrule", "FREQ=MONTHLY;BYDAY=-1FR;COUNT=6"),
+				),
+			},
+			{
+				Config: testAccSquareCatalogTimePeriodConfigOnce("2020-12-31T20:00:00", "P1D"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("square_catalog_time_period.test", &id, false),
+					resource.TestCheckResourceAttr("square_catalog_time_period.test", "summary", ""),
+					resource.TestCheckResourceAttr("square_catalog_time_period.test", "start", "2020-12-31T20:00:00"),
+					resource.TestCheckResourceAttr("square_catalog_time_period.test", "duration", "P1D"),
+					resource.TestCheckResourceAttr("square_catalog_time_period.test", "rrule", ""),
+				),
+			},
+			{
+				ResourceName:            "square_catalog_time_period.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"idempotency_key"},
+			},
+		},
+	})
+}
+
+func TestAccSquareCatalogTimePeriod_invalid(t *testing.T) {
+	testAccRunRejectedConfigs(t, []testAccRejectedConfig{
+		{
+			name:   "rule without frequency",
+			config: testAccSquareCatalogTimePeriodConfigRecurring("Happy hour", "2020-12-07T17:00:00", "PT2H", "BYDAY=MO,XX;COUNT=0"),
+			err:    "FREQ is required",
+		},
+		{
+			name:   "rule with count and until",
+			config: testAccSquareCatalogTimePeriodConfigRecurring("Happy hour", "2020-12-07T17:00:00", "PT2H", "FREQ=DAILY;COUNT=3;UNTIL=20201231"),
+			err:    "COUNT and UNTIL cannot both be set",
+		},
+		{
+			name:   "rule with unknown weekday",
+			config: testAccSquareCatalogTimePeriodConfigRecurring("Happy hour", "2020-12-07T17:00:00", "PT2H", "FREQ=WEEKLY;BYDAY=XX"),
+			err:    "BYDAY 'XX' is not a weekday",
+		},
+		{
+			name:   "rule with hour out of range",
+			config: testAccSquareCatalogTimePeriodConfigRecurring("Happy hour", "2020-12-07T17:00:00", "PT2H", "FREQ=DAILY;BYHOUR=24"),
+			err:    "BYHOUR '24' must be between 0 and 23",
+		},
+		{
+			name:   "duration not in ISO 8601",
+			config: testAccSquareCatalogTimePeriodConfigRecurring("Happy hour", "2020-12-07 17:00", "2h", "FREQ=DAILY"),
+			err:    "is not an ISO 8601 duration",
+		},
+	})
+}
+
+func testAccSquareCatalogTimePeriodConfigRecurring(summary, start, duration, rrule string) string {
+	return fmt.Sprintf(`
+resource "square_catalog_time_period" "test" {
+  summary  = %q
+  start    = %q
+  duration = %q
+  rrule    = %q
+}
+`, summary, start, duration, rrule)
+}
+
+func testAccSquareCatalogTimePeriodConfigOnce(start, duration string) string {
+	return fmt.Sprintf(`
+resource "square_catalog_time_period" "test" {
+  start    = %q
+  duration = %q
+}
+`, start, duration)
+}
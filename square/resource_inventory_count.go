@@ -0,0 +1,168 @@
+package square
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	squaremodel "github.com/jefflinse/square-connect/models"
+	"github.com/jefflinse/terraform-provider-square/square/client"
+)
+
+const (
+	// InventoryChangeTypePhysicalCount is the Square type for an inventory change recording a physical count.
+	InventoryChangeTypePhysicalCount = "PHYSICAL_COUNT"
+
+	// InventoryStateInStock is the Square state of inventory that is available to sell.
+	InventoryStateInStock = "IN_STOCK"
+)
+
+// inventoryQuantityPattern matches an inventory quantity, a non-negative decimal with at most 5 decimal places.
+var inventoryQuantityPattern = regexp.MustCompile(`^\d+(\.\d{1,5})?$`)
+
+// Maps Square inventory change fields to the attributes they are configured by.
+var inventoryCountFields = map[string]string{
+	"changes[0].physical_count.catalog_object_id": "catalog_object_id",
+	"changes[0].physical_count.location_id":       "location_id",
+	"changes[0].physical_count.quantity":          "quantity",
+}
+
+func resourceSquareInventoryCount() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"calculated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"catalog_object_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"current_quantity": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"location_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"quantity": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(v interface{}, k string) (wrns []string, errs []error) {
+					val := v.(string)
+					if !inventoryQuantityPattern.MatchString(val) {
+						errs = append(errs, fmt.Errorf("%s '%s' is not a non-negative number with at most 5 decimal places", k, val))
+					}
+					return
+				},
+				// Square may write a quantity differently, e.g. "10.00" as "10".
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					o, oldOK := big.NewRat(0, 1).SetString(old)
+					n, newOK := big.NewRat(0, 1).SetString(new)
+					return oldOK && newOK && o.Cmp(n) == 0
+				},
+			},
+		},
+		Create: resourceSquareInventoryCountCreate,
+		Read:   resourceSquareInventoryCountRead,
+		Update: resourceSquareInventoryCountUpdate,
+		Delete: resourceSquareInventoryCountDelete,
+		CustomizeDiff: func(d *schema.ResourceDiff, meta interface{}) error {
+			if id := d.Get("location_id").(string); id != "" {
+				return validateLocationsExist(meta, []string{id})
+			}
+			return nil
+		},
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				parts := strings.Split(d.Id(), ":")
+				if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+					return nil, fmt.Errorf("cannot import inventory count %s: expected an ID of the form <catalog_object_id>:<location_id>", d.Id())
+				}
+
+				d.Set("catalog_object_id", parts[0])
+				d.Set("location_id", parts[1])
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+	}
+}
+
+func resourceSquareInventoryCountCreate(d *schema.ResourceData, meta interface{}) error {
+	if err := setInventoryCount(d, meta); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", d.Get("catalog_object_id").(string), d.Get("location_id").(string)))
+
+	return resourceSquareInventoryCountRead(d, meta)
+}
+
+func resourceSquareInventoryCountRead(d *schema.ResourceData, meta interface{}) error {
+	count, err := meta.(client.SquareAPI).RetrieveInventoryCount(d.Get("catalog_object_id").(string), d.Get("location_id").(string), InventoryStateInStock)
+	if errors.Is(err, client.ErrNotFound) {
+		log.Printf("[WARN] inventory count %s no longer exists, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	d.Set("calculated_at", count.CalculatedAt)
+	d.Set("catalog_object_id", count.CatalogObjectID)
+	d.Set("location_id", count.LocationID)
+	d.Set("current_quantity", count.Quantity)
+
+	// The configured quantity is what was counted, and stock selling since is not drift. Only an
+	// imported count, which has yet to be configured, takes its quantity from Square.
+	if d.Get("quantity").(string) == "" {
+		d.Set("quantity", count.Quantity)
+	}
+
+	return nil
+}
+
+func resourceSquareInventoryCountUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("quantity") {
+		if err := setInventoryCount(d, meta); err != nil {
+			return err
+		}
+	}
+
+	return resourceSquareInventoryCountRead(d, meta)
+}
+
+// Inventory cannot be deleted, and zeroing it would throw away stock that was counted or sold
+// since, so a count that is no longer managed is left as it is.
+func resourceSquareInventoryCountDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] inventory count %s is no longer managed; its quantity is left unchanged in Square", d.Id())
+	return nil
+}
+
+// Records a physical count of the configured quantity, which replaces the IN_STOCK quantity.
+func setInventoryCount(d *schema.ResourceData, meta interface{}) error {
+	_, err := meta.(client.SquareAPI).BatchChangeInventory([]*squaremodel.InventoryChange{{
+		Type: InventoryChangeTypePhysicalCount,
+		PhysicalCount: &squaremodel.InventoryPhysicalCount{
+			CatalogObjectID: d.Get("catalog_object_id").(string),
+			LocationID:      d.Get("location_id").(string),
+			OccurredAt:      time.Now().UTC().Format(time.RFC3339),
+			Quantity:        d.Get("quantity").(string),
+			State:           InventoryStateInStock,
+		},
+	}})
+	if err != nil {
+		return attributeErrors(err, inventoryCountFields)
+	}
+
+	return nil
+}
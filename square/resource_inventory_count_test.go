@@ -0,0 +1,152 @@
+package square
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccSquareInventoryCount_update(t *testing.T) {
+	location, _ := testAccLocationIDs(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_item_variation"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareInventoryCountConfig(location, "12"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_inventory_count.test", "quantity", "12"),
+					resource.TestCheckResourceAttr("square_inventory_count.test", "current_quantity", "12"),
+					resource.TestCheckResourceAttrPair("square_inventory_count.test", "catalog_object_id", "square_catalog_item_variation.test", "id"),
+					resource.TestCheckResourceAttr("square_inventory_count.test", "location_id", location),
+					resource.TestCheckResourceAttrSet("square_inventory_count.test", "calculated_at"),
+				),
+			},
+			{
+				Config: testAccSquareInventoryCountConfig(location, "7.5"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_inventory_count.test", "quantity", "7.5"),
+				),
+			},
+			{
+				// The same quantity written differently changes nothing.
+				Config:   testAccSquareInventoryCountConfig(location, "7.50"),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:      "square_inventory_count.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccSquareInventoryCount_sold(t *testing.T) {
+	location, _ := testAccLocationIDs(t)
+
+	var variationID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckFakeServer(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCatalogObjectsDestroyed("square_catalog_item_variation"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSquareInventoryCountConfig(location, "12"),
+				Check:  testAccCheckResourceID("square_catalog_item_variation.test", &variationID, false),
+			},
+			{
+				// Selling stock is not drift, so nothing is counted again.
+				PreConfig: func() {
+					if !testAccServer.SetInventoryCount(variationID, location, "9") {
+						t.Fatalf("variation %s has no inventory count at %s", variationID, location)
+					}
+				},
+				Config: testAccSquareInventoryCountConfig(location, "12"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("square_inventory_count.test", "quantity", "12"),
+					resource.TestCheckResourceAttr("square_inventory_count.test", "current_quantity", "9"),
+					func(*terraform.State) error {
+						if quantity, _ := testAccServer.InventoryCount(variationID, location); quantity != "9" {
+							return fmt.Errorf("stock is %s, want the 9 left after the sale", quantity)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:   testAccSquareInventoryCountConfig(location, "12"),
+				PlanOnly: true,
+			},
+			{
+				// Changing the configured quantity counts the stock again.
+				Config: testAccSquareInventoryCountConfig(location, "20"),
+				Check:  resource.TestCheckResourceAttr("square_inventory_count.test", "current_quantity", "20"),
+			},
+		},
+	})
+}
+
+func TestAccSquareInventoryCount_invalid(t *testing.T) {
+	location, _ := testAccLocationIDs(t)
+
+	testAccRunRejectedConfigs(t, []testAccRejectedConfig{
+		{
+			name:   "negative quantity",
+			config: testAccSquareInventoryCountConfig(location, "-3"),
+			err:    "is not a non-negative number with at most 5 decimal places",
+		},
+		{
+			name:   "too many decimal places",
+			config: testAccSquareInventoryCountConfig(location, "1.123456"),
+			err:    "is not a non-negative number with at most 5 decimal places",
+		},
+		{
+			name:   "unknown location",
+			config: testAccSquareInventoryCountConfig("NOSUCHLOCATION", "3"),
+			err:    "locations do not exist: NOSUCHLOCATION",
+		},
+	})
+}
+
+func TestAccSquareInventoryCount_importInvalidID(t *testing.T) {
+	location, _ := testAccLocationIDs(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				ResourceName:  "square_inventory_count.test",
+				ImportState:   true,
+				ImportStateId: "VARIATION",
+				Config:        testAccSquareInventoryCountConfig(location, "3"),
+				ExpectError:   regexp.MustCompile("expected an ID of the form <catalog_object_id>:<location_id>"),
+			},
+		},
+	})
+}
+
+func testAccSquareInventoryCountConfig(locationID, quantity string) string {
+	return testAccCatalogItemConfig("test", "Travel mug") + fmt.Sprintf(`
+resource "square_catalog_item_variation" "test" {
+  item_id         = square_catalog_item.test.id
+  name            = "Regular"
+  pricing_type    = "FIXED_PRICING"
+  price           = 1500
+  currency        = "USD"
+  track_inventory = true
+}
+
+resource "square_inventory_count" "test" {
+  catalog_object_id = square_catalog_item_variation.test.id
+  location_id       = %q
+  quantity          = %q
+}
+`, locationID, quantity)
+}
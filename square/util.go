@@ -2,16 +2,84 @@ package square
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/jefflinse/terraform-provider-square/square/client"
 )
 
-// Generates a new temporary client ID for creating a new Square object.
-func newTempID() *string {
-	return strPtr(fmt.Sprint("#", uuid.New().String()))
+// Returns an importer that adopts an existing catalog object by its Square ID, refusing to import
+// objects whose type differs from objectType.
+func importCatalogObject(objectType string) *schema.ResourceImporter {
+	return &schema.ResourceImporter{
+		State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			obj, err := meta.(client.SquareAPI).RetrieveCatalogObject(d.Id())
+			if err != nil {
+				return nil, fmt.Errorf("cannot import catalog object %s: %w", d.Id(), err)
+			}
+
+			if obj.Type == nil || *obj.Type != objectType {
+				actual := "<unknown>"
+				if obj.Type != nil {
+					actual = *obj.Type
+				}
+				return nil, fmt.Errorf("cannot import catalog object %s: it is a %s, not a %s", d.Id(), actual, objectType)
+			}
+
+			return []*schema.ResourceData{d}, nil
+		},
+	}
 }
 
 // Returns a pointer to the specified string value.
 func strPtr(value string) *string {
 	return &value
 }
+
+// Returns a validator ensuring a string attribute can be parsed with the given time layout.
+func validateTimeLayout(layout, description string) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (wrns []string, errs []error) {
+		val := v.(string)
+		if _, err := time.Parse(layout, val); err != nil {
+			errs = append(errs, fmt.Errorf("%s '%s' is not a %s", k, val, description))
+		}
+		return
+	}
+}
+
+// Validates that a string attribute holds a non-negative Go duration (e.g. "30s").
+func validateDuration(v interface{}, k string) (wrns []string, errs []error) {
+	val := v.(string)
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%s '%s' is not a valid duration: %s", k, val, err))
+	} else if d < 0 {
+		errs = append(errs, fmt.Errorf("%s '%s' must not be negative", k, val))
+	}
+	return
+}
+
+// Adds the attribute holding the key a resource's catalog object is created with to a resource
+// schema. The key is random and generated once per resource, so that a create that is retried
+// repeats the same write, while identical resources create objects of their own.
+func withIdempotencyKey(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["idempotency_key"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+
+	return s
+}
+
+// Returns the temporary ID a resource's catalog object is created with, generating the resource's
+// idempotency key if it has none yet.
+func newCatalogObjectID(d *schema.ResourceData) *string {
+	key := d.Get("idempotency_key").(string)
+	if key == "" {
+		key = uuid.New().String()
+		d.Set("idempotency_key", key)
+	}
+
+	return strPtr("#" + key)
+}